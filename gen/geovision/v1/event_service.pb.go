@@ -10,8 +10,10 @@ import (
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
 	v1 "github.com/omnsight/omniscent-library/gen/model/v1"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,30 +26,3046 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ExportFormat int32
+
+const (
+	// Defaults to CSV
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
+	ExportFormat_EXPORT_FORMAT_CSV         ExportFormat = 1
+	ExportFormat_EXPORT_FORMAT_PARQUET     ExportFormat = 2
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_CSV",
+		2: "EXPORT_FORMAT_PARQUET",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_CSV":         1,
+		"EXPORT_FORMAT_PARQUET":     2,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_geovision_v1_event_service_proto_enumTypes[0].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_geovision_v1_event_service_proto_enumTypes[0]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{0}
+}
+
+type GraphFormat int32
+
+const (
+	// Defaults to GraphML
+	GraphFormat_GRAPH_FORMAT_UNSPECIFIED GraphFormat = 0
+	GraphFormat_GRAPH_FORMAT_GRAPHML     GraphFormat = 1
+	GraphFormat_GRAPH_FORMAT_GEXF        GraphFormat = 2
+)
+
+// Enum value maps for GraphFormat.
+var (
+	GraphFormat_name = map[int32]string{
+		0: "GRAPH_FORMAT_UNSPECIFIED",
+		1: "GRAPH_FORMAT_GRAPHML",
+		2: "GRAPH_FORMAT_GEXF",
+	}
+	GraphFormat_value = map[string]int32{
+		"GRAPH_FORMAT_UNSPECIFIED": 0,
+		"GRAPH_FORMAT_GRAPHML":     1,
+		"GRAPH_FORMAT_GEXF":        2,
+	}
+)
+
+func (x GraphFormat) Enum() *GraphFormat {
+	p := new(GraphFormat)
+	*p = x
+	return p
+}
+
+func (x GraphFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GraphFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_geovision_v1_event_service_proto_enumTypes[1].Descriptor()
+}
+
+func (GraphFormat) Type() protoreflect.EnumType {
+	return &file_geovision_v1_event_service_proto_enumTypes[1]
+}
+
+func (x GraphFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GraphFormat.Descriptor instead.
+func (GraphFormat) EnumDescriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{1}
+}
+
+// Anomaly messages
+type RegionGrouping int32
+
+const (
+	// Defaults to grouping by country code
+	RegionGrouping_REGION_GROUPING_UNSPECIFIED  RegionGrouping = 0
+	RegionGrouping_REGION_GROUPING_COUNTRY_CODE RegionGrouping = 1
+	RegionGrouping_REGION_GROUPING_GEOHASH      RegionGrouping = 2
+)
+
+// Enum value maps for RegionGrouping.
+var (
+	RegionGrouping_name = map[int32]string{
+		0: "REGION_GROUPING_UNSPECIFIED",
+		1: "REGION_GROUPING_COUNTRY_CODE",
+		2: "REGION_GROUPING_GEOHASH",
+	}
+	RegionGrouping_value = map[string]int32{
+		"REGION_GROUPING_UNSPECIFIED":  0,
+		"REGION_GROUPING_COUNTRY_CODE": 1,
+		"REGION_GROUPING_GEOHASH":      2,
+	}
+)
+
+func (x RegionGrouping) Enum() *RegionGrouping {
+	p := new(RegionGrouping)
+	*p = x
+	return p
+}
+
+func (x RegionGrouping) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegionGrouping) Descriptor() protoreflect.EnumDescriptor {
+	return file_geovision_v1_event_service_proto_enumTypes[2].Descriptor()
+}
+
+func (RegionGrouping) Type() protoreflect.EnumType {
+	return &file_geovision_v1_event_service_proto_enumTypes[2]
+}
+
+func (x RegionGrouping) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegionGrouping.Descriptor instead.
+func (RegionGrouping) EnumDescriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{2}
+}
+
+type AnomalyDimension int32
+
+const (
+	AnomalyDimension_ANOMALY_DIMENSION_UNSPECIFIED AnomalyDimension = 0
+	AnomalyDimension_ANOMALY_DIMENSION_REGION      AnomalyDimension = 1
+	AnomalyDimension_ANOMALY_DIMENSION_TAG         AnomalyDimension = 2
+)
+
+// Enum value maps for AnomalyDimension.
+var (
+	AnomalyDimension_name = map[int32]string{
+		0: "ANOMALY_DIMENSION_UNSPECIFIED",
+		1: "ANOMALY_DIMENSION_REGION",
+		2: "ANOMALY_DIMENSION_TAG",
+	}
+	AnomalyDimension_value = map[string]int32{
+		"ANOMALY_DIMENSION_UNSPECIFIED": 0,
+		"ANOMALY_DIMENSION_REGION":      1,
+		"ANOMALY_DIMENSION_TAG":         2,
+	}
+)
+
+func (x AnomalyDimension) Enum() *AnomalyDimension {
+	p := new(AnomalyDimension)
+	*p = x
+	return p
+}
+
+func (x AnomalyDimension) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnomalyDimension) Descriptor() protoreflect.EnumDescriptor {
+	return file_geovision_v1_event_service_proto_enumTypes[3].Descriptor()
+}
+
+func (AnomalyDimension) Type() protoreflect.EnumType {
+	return &file_geovision_v1_event_service_proto_enumTypes[3]
+}
+
+func (x AnomalyDimension) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnomalyDimension.Descriptor instead.
+func (AnomalyDimension) EnumDescriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{3}
+}
+
+type AnomalyDirection int32
+
+const (
+	AnomalyDirection_ANOMALY_DIRECTION_UNSPECIFIED AnomalyDirection = 0
+	AnomalyDirection_ANOMALY_DIRECTION_SPIKE       AnomalyDirection = 1
+	AnomalyDirection_ANOMALY_DIRECTION_DROP        AnomalyDirection = 2
+)
+
+// Enum value maps for AnomalyDirection.
+var (
+	AnomalyDirection_name = map[int32]string{
+		0: "ANOMALY_DIRECTION_UNSPECIFIED",
+		1: "ANOMALY_DIRECTION_SPIKE",
+		2: "ANOMALY_DIRECTION_DROP",
+	}
+	AnomalyDirection_value = map[string]int32{
+		"ANOMALY_DIRECTION_UNSPECIFIED": 0,
+		"ANOMALY_DIRECTION_SPIKE":       1,
+		"ANOMALY_DIRECTION_DROP":        2,
+	}
+)
+
+func (x AnomalyDirection) Enum() *AnomalyDirection {
+	p := new(AnomalyDirection)
+	*p = x
+	return p
+}
+
+func (x AnomalyDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnomalyDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_geovision_v1_event_service_proto_enumTypes[4].Descriptor()
+}
+
+func (AnomalyDirection) Type() protoreflect.EnumType {
+	return &file_geovision_v1_event_service_proto_enumTypes[4]
+}
+
+func (x AnomalyDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnomalyDirection.Descriptor instead.
+func (AnomalyDirection) EnumDescriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{4}
+}
+
+type ImportFormat int32
+
+const (
+	ImportFormat_IMPORT_FORMAT_UNSPECIFIED ImportFormat = 0
+	// CSV export of the ACLED data portal, with a header row
+	ImportFormat_IMPORT_FORMAT_ACLED ImportFormat = 1
+	// Tab separated GDELT 2.0 events file
+	ImportFormat_IMPORT_FORMAT_GDELT ImportFormat = 2
+)
+
+// Enum value maps for ImportFormat.
+var (
+	ImportFormat_name = map[int32]string{
+		0: "IMPORT_FORMAT_UNSPECIFIED",
+		1: "IMPORT_FORMAT_ACLED",
+		2: "IMPORT_FORMAT_GDELT",
+	}
+	ImportFormat_value = map[string]int32{
+		"IMPORT_FORMAT_UNSPECIFIED": 0,
+		"IMPORT_FORMAT_ACLED":       1,
+		"IMPORT_FORMAT_GDELT":       2,
+	}
+)
+
+func (x ImportFormat) Enum() *ImportFormat {
+	p := new(ImportFormat)
+	*p = x
+	return p
+}
+
+func (x ImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_geovision_v1_event_service_proto_enumTypes[5].Descriptor()
+}
+
+func (ImportFormat) Type() protoreflect.EnumType {
+	return &file_geovision_v1_event_service_proto_enumTypes[5]
+}
+
+func (x ImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportFormat.Descriptor instead.
+func (ImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{5}
+}
+
+type CentralityMeasure int32
+
+const (
+	// Defaults to PageRank
+	CentralityMeasure_CENTRALITY_MEASURE_UNSPECIFIED CentralityMeasure = 0
+	CentralityMeasure_CENTRALITY_MEASURE_DEGREE      CentralityMeasure = 1
+	CentralityMeasure_CENTRALITY_MEASURE_BETWEENNESS CentralityMeasure = 2
+	CentralityMeasure_CENTRALITY_MEASURE_PAGERANK    CentralityMeasure = 3
+)
+
+// Enum value maps for CentralityMeasure.
+var (
+	CentralityMeasure_name = map[int32]string{
+		0: "CENTRALITY_MEASURE_UNSPECIFIED",
+		1: "CENTRALITY_MEASURE_DEGREE",
+		2: "CENTRALITY_MEASURE_BETWEENNESS",
+		3: "CENTRALITY_MEASURE_PAGERANK",
+	}
+	CentralityMeasure_value = map[string]int32{
+		"CENTRALITY_MEASURE_UNSPECIFIED": 0,
+		"CENTRALITY_MEASURE_DEGREE":      1,
+		"CENTRALITY_MEASURE_BETWEENNESS": 2,
+		"CENTRALITY_MEASURE_PAGERANK":    3,
+	}
+)
+
+func (x CentralityMeasure) Enum() *CentralityMeasure {
+	p := new(CentralityMeasure)
+	*p = x
+	return p
+}
+
+func (x CentralityMeasure) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CentralityMeasure) Descriptor() protoreflect.EnumDescriptor {
+	return file_geovision_v1_event_service_proto_enumTypes[6].Descriptor()
+}
+
+func (CentralityMeasure) Type() protoreflect.EnumType {
+	return &file_geovision_v1_event_service_proto_enumTypes[6]
+}
+
+func (x CentralityMeasure) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CentralityMeasure.Descriptor instead.
+func (CentralityMeasure) EnumDescriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{6}
+}
+
 // Event messages
 type GetEventsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartTime int64                  `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   int64                  `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Only return events located inside this area, if set
+	Bbox          *BoundingBox `protobuf:"bytes,3,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventsRequest) Reset() {
+	*x = GetEventsRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsRequest) ProtoMessage() {}
+
+func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetEventsRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetEventsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetEventsRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetEventsRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+type GetEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartTime     int64                  `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       int64                  `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Relations     []*v1.Relation         `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty"`
+	Events        []*v1.Event            `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventsResponse) Reset() {
+	*x = GetEventsResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsResponse) ProtoMessage() {}
+
+func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetEventsResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetEventsResponse) GetRelations() []*v1.Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetEvents() []*v1.Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ExportEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same filters as GetEventsRequest
+	StartTime int64        `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   int64        `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Bbox      *BoundingBox `protobuf:"bytes,3,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	Format    ExportFormat `protobuf:"varint,4,opt,name=format,proto3,enum=geovision.v1.ExportFormat" json:"format,omitempty"`
+	// Columns to include, in order; defaults to all columns
+	Columns []string `protobuf:"bytes,5,rep,name=columns,proto3" json:"columns,omitempty"`
+	// Compress the output with gzip
+	Gzip          bool `protobuf:"varint,6,opt,name=gzip,proto3" json:"gzip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportEventsRequest) Reset() {
+	*x = ExportEventsRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportEventsRequest) ProtoMessage() {}
+
+func (x *ExportEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportEventsRequest.ProtoReflect.Descriptor instead.
+func (*ExportEventsRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportEventsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ExportEventsRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ExportEventsRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+func (x *ExportEventsRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportEventsRequest) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *ExportEventsRequest) GetGzip() bool {
+	if x != nil {
+		return x.Gzip
+	}
+	return false
+}
+
+type ExportStixRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same filters as GetEventsRequest
+	StartTime     int64        `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       int64        `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Bbox          *BoundingBox `protobuf:"bytes,3,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStixRequest) Reset() {
+	*x = ExportStixRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStixRequest) ProtoMessage() {}
+
+func (x *ExportStixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStixRequest.ProtoReflect.Descriptor instead.
+func (*ExportStixRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExportStixRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+type ExportGraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same filters as GetEventsRequest
+	StartTime int64        `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   int64        `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Bbox      *BoundingBox `protobuf:"bytes,3,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	// Number of relations to follow from the matching events, in either
+	// direction; defaults to 1
+	Depth         int32       `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	Format        GraphFormat `protobuf:"varint,5,opt,name=format,proto3,enum=geovision.v1.GraphFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportGraphRequest) Reset() {
+	*x = ExportGraphRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGraphRequest) ProtoMessage() {}
+
+func (x *ExportGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportGraphRequest.ProtoReflect.Descriptor instead.
+func (*ExportGraphRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExportGraphRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+func (x *ExportGraphRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetFormat() GraphFormat {
+	if x != nil {
+		return x.Format
+	}
+	return GraphFormat_GRAPH_FORMAT_UNSPECIFIED
+}
+
+type GetEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRequest) Reset() {
+	*x = GetEventRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRequest) ProtoMessage() {}
+
+func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetEventRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *v1.Event              `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventResponse) Reset() {
+	*x = GetEventResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventResponse) ProtoMessage() {}
+
+func (x *GetEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventResponse.ProtoReflect.Descriptor instead.
+func (*GetEventResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetEventResponse) GetEvent() *v1.Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type GetEventRelatedEntitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRelatedEntitiesRequest) Reset() {
+	*x = GetEventRelatedEntitiesRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRelatedEntitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRelatedEntitiesRequest) ProtoMessage() {}
+
+func (x *GetEventRelatedEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRelatedEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRelatedEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetEventRelatedEntitiesRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetEventRelatedEntitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entities      []*v1.RelatedEntity    `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRelatedEntitiesResponse) Reset() {
+	*x = GetEventRelatedEntitiesResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRelatedEntitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRelatedEntitiesResponse) ProtoMessage() {}
+
+func (x *GetEventRelatedEntitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRelatedEntitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetEventRelatedEntitiesResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetEventRelatedEntitiesResponse) GetEntities() []*v1.RelatedEntity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+// Common geo messages
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLatitude   float64                `protobuf:"fixed64,1,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`
+	MinLongitude  float64                `protobuf:"fixed64,2,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"`
+	MaxLatitude   float64                `protobuf:"fixed64,3,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`
+	MaxLongitude  float64                `protobuf:"fixed64,4,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BoundingBox) GetMinLatitude() float64 {
+	if x != nil {
+		return x.MinLatitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMinLongitude() float64 {
+	if x != nil {
+		return x.MinLongitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLatitude() float64 {
+	if x != nil {
+		return x.MaxLatitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLongitude() float64 {
+	if x != nil {
+		return x.MaxLongitude
+	}
+	return 0
+}
+
+// Entity messages
+type GetEntityFootprintRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Document ID of the entity, e.g. "persons/123"
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntityFootprintRequest) Reset() {
+	*x = GetEntityFootprintRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntityFootprintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntityFootprintRequest) ProtoMessage() {}
+
+func (x *GetEntityFootprintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntityFootprintRequest.ProtoReflect.Descriptor instead.
+func (*GetEntityFootprintRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetEntityFootprintRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetEntityFootprintResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Events linked to the entity, ordered by happened_at
+	Events []*v1.Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// GeoJSON FeatureCollection with the track LineString and one Point per event
+	Track         *structpb.Struct `protobuf:"bytes,2,opt,name=track,proto3" json:"track,omitempty"`
+	FirstSeen     int64            `protobuf:"varint,3,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen      int64            `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Bbox          *BoundingBox     `protobuf:"bytes,5,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntityFootprintResponse) Reset() {
+	*x = GetEntityFootprintResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntityFootprintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntityFootprintResponse) ProtoMessage() {}
+
+func (x *GetEntityFootprintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntityFootprintResponse.ProtoReflect.Descriptor instead.
+func (*GetEntityFootprintResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetEntityFootprintResponse) GetEvents() []*v1.Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetEntityFootprintResponse) GetTrack() *structpb.Struct {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+func (x *GetEntityFootprintResponse) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *GetEntityFootprintResponse) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *GetEntityFootprintResponse) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+type FindCoLocatedEntitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Document ID of the target entity, e.g. "persons/123"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Maximum distance in meters between two events to count as co-located,
+	// defaults to 0 so that only events at the same coordinates match
+	DistanceMeters float64 `protobuf:"fixed64,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	// Maximum difference between happened_at values, in seconds, to count as
+	// co-located, defaults to 0 so that only events at the same second match.
+	// An event of the target is never co-located with itself.
+	TimeTolerance int64 `protobuf:"varint,3,opt,name=time_tolerance,json=timeTolerance,proto3" json:"time_tolerance,omitempty"`
+	// Minimum number of co-occurrences for an entity to be returned, defaults to 1
+	MinCoOccurrences int32 `protobuf:"varint,4,opt,name=min_co_occurrences,json=minCoOccurrences,proto3" json:"min_co_occurrences,omitempty"`
+	// Maximum number of entities to return, defaults to 20
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindCoLocatedEntitiesRequest) Reset() {
+	*x = FindCoLocatedEntitiesRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindCoLocatedEntitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindCoLocatedEntitiesRequest) ProtoMessage() {}
+
+func (x *FindCoLocatedEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindCoLocatedEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*FindCoLocatedEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FindCoLocatedEntitiesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FindCoLocatedEntitiesRequest) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *FindCoLocatedEntitiesRequest) GetTimeTolerance() int64 {
+	if x != nil {
+		return x.TimeTolerance
+	}
+	return 0
+}
+
+func (x *FindCoLocatedEntitiesRequest) GetMinCoOccurrences() int32 {
+	if x != nil {
+		return x.MinCoOccurrences
+	}
+	return 0
+}
+
+func (x *FindCoLocatedEntitiesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CoLocation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetEvent    *v1.Event              `protobuf:"bytes,1,opt,name=target_event,json=targetEvent,proto3" json:"target_event,omitempty"`
+	Event          *v1.Event              `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	DistanceMeters float64                `protobuf:"fixed64,3,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	TimeDelta      int64                  `protobuf:"varint,4,opt,name=time_delta,json=timeDelta,proto3" json:"time_delta,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CoLocation) Reset() {
+	*x = CoLocation{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoLocation) ProtoMessage() {}
+
+func (x *CoLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoLocation.ProtoReflect.Descriptor instead.
+func (*CoLocation) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CoLocation) GetTargetEvent() *v1.Event {
+	if x != nil {
+		return x.TargetEvent
+	}
+	return nil
+}
+
+func (x *CoLocation) GetEvent() *v1.Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *CoLocation) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *CoLocation) GetTimeDelta() int64 {
+	if x != nil {
+		return x.TimeDelta
+	}
+	return 0
+}
+
+type CoLocatedEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        *v1.RelatedEntity      `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	CoOccurrences int32                  `protobuf:"varint,2,opt,name=co_occurrences,json=coOccurrences,proto3" json:"co_occurrences,omitempty"`
+	CoLocations   []*CoLocation          `protobuf:"bytes,3,rep,name=co_locations,json=coLocations,proto3" json:"co_locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoLocatedEntity) Reset() {
+	*x = CoLocatedEntity{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoLocatedEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoLocatedEntity) ProtoMessage() {}
+
+func (x *CoLocatedEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoLocatedEntity.ProtoReflect.Descriptor instead.
+func (*CoLocatedEntity) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CoLocatedEntity) GetEntity() *v1.RelatedEntity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *CoLocatedEntity) GetCoOccurrences() int32 {
+	if x != nil {
+		return x.CoOccurrences
+	}
+	return 0
+}
+
+func (x *CoLocatedEntity) GetCoLocations() []*CoLocation {
+	if x != nil {
+		return x.CoLocations
+	}
+	return nil
+}
+
+type FindCoLocatedEntitiesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entities ordered by number of co-occurrences, highest first
+	Entities      []*CoLocatedEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindCoLocatedEntitiesResponse) Reset() {
+	*x = FindCoLocatedEntitiesResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindCoLocatedEntitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindCoLocatedEntitiesResponse) ProtoMessage() {}
+
+func (x *FindCoLocatedEntitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindCoLocatedEntitiesResponse.ProtoReflect.Descriptor instead.
+func (*FindCoLocatedEntitiesResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FindCoLocatedEntitiesResponse) GetEntities() []*CoLocatedEntity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+type GetAnomaliesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartTime int64                  `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Exclusive end of the observed window
+	EndTime        int64          `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	RegionGrouping RegionGrouping `protobuf:"varint,3,opt,name=region_grouping,json=regionGrouping,proto3,enum=geovision.v1.RegionGrouping" json:"region_grouping,omitempty"`
+	// Geohash length used with REGION_GROUPING_GEOHASH, defaults to 4
+	GeohashPrecision int32 `protobuf:"varint,4,opt,name=geohash_precision,json=geohashPrecision,proto3" json:"geohash_precision,omitempty"`
+	// Number of preceding windows of the same length used as baseline, defaults to 8
+	BaselineWindows int32 `protobuf:"varint,5,opt,name=baseline_windows,json=baselineWindows,proto3" json:"baseline_windows,omitempty"`
+	// Minimum absolute z-score to flag a count as anomalous, defaults to 3
+	Threshold     float64 `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnomaliesRequest) Reset() {
+	*x = GetAnomaliesRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnomaliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnomaliesRequest) ProtoMessage() {}
+
+func (x *GetAnomaliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnomaliesRequest.ProtoReflect.Descriptor instead.
+func (*GetAnomaliesRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetAnomaliesRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetAnomaliesRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetAnomaliesRequest) GetRegionGrouping() RegionGrouping {
+	if x != nil {
+		return x.RegionGrouping
+	}
+	return RegionGrouping_REGION_GROUPING_UNSPECIFIED
+}
+
+func (x *GetAnomaliesRequest) GetGeohashPrecision() int32 {
+	if x != nil {
+		return x.GeohashPrecision
+	}
+	return 0
+}
+
+func (x *GetAnomaliesRequest) GetBaselineWindows() int32 {
+	if x != nil {
+		return x.BaselineWindows
+	}
+	return 0
+}
+
+func (x *GetAnomaliesRequest) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type Anomaly struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Dimension AnomalyDimension       `protobuf:"varint,1,opt,name=dimension,proto3,enum=geovision.v1.AnomalyDimension" json:"dimension,omitempty"`
+	// Country code, geohash cell or tag
+	Key           string           `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Direction     AnomalyDirection `protobuf:"varint,3,opt,name=direction,proto3,enum=geovision.v1.AnomalyDirection" json:"direction,omitempty"`
+	Observed      int64            `protobuf:"varint,4,opt,name=observed,proto3" json:"observed,omitempty"`
+	Expected      float64          `protobuf:"fixed64,5,opt,name=expected,proto3" json:"expected,omitempty"`
+	Stddev        float64          `protobuf:"fixed64,6,opt,name=stddev,proto3" json:"stddev,omitempty"`
+	ZScore        float64          `protobuf:"fixed64,7,opt,name=z_score,json=zScore,proto3" json:"z_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Anomaly) Reset() {
+	*x = Anomaly{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Anomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Anomaly) ProtoMessage() {}
+
+func (x *Anomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Anomaly.ProtoReflect.Descriptor instead.
+func (*Anomaly) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Anomaly) GetDimension() AnomalyDimension {
+	if x != nil {
+		return x.Dimension
+	}
+	return AnomalyDimension_ANOMALY_DIMENSION_UNSPECIFIED
+}
+
+func (x *Anomaly) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Anomaly) GetDirection() AnomalyDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return AnomalyDirection_ANOMALY_DIRECTION_UNSPECIFIED
+}
+
+func (x *Anomaly) GetObserved() int64 {
+	if x != nil {
+		return x.Observed
+	}
+	return 0
+}
+
+func (x *Anomaly) GetExpected() float64 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *Anomaly) GetStddev() float64 {
+	if x != nil {
+		return x.Stddev
+	}
+	return 0
+}
+
+func (x *Anomaly) GetZScore() float64 {
+	if x != nil {
+		return x.ZScore
+	}
+	return 0
+}
+
+type GetAnomaliesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Anomalies ordered by absolute z-score, highest first
+	Anomalies         []*Anomaly `protobuf:"bytes,1,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	BaselineStartTime int64      `protobuf:"varint,2,opt,name=baseline_start_time,json=baselineStartTime,proto3" json:"baseline_start_time,omitempty"`
+	BaselineEndTime   int64      `protobuf:"varint,3,opt,name=baseline_end_time,json=baselineEndTime,proto3" json:"baseline_end_time,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetAnomaliesResponse) Reset() {
+	*x = GetAnomaliesResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnomaliesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnomaliesResponse) ProtoMessage() {}
+
+func (x *GetAnomaliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnomaliesResponse.ProtoReflect.Descriptor instead.
+func (*GetAnomaliesResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetAnomaliesResponse) GetAnomalies() []*Anomaly {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
+}
+
+func (x *GetAnomaliesResponse) GetBaselineStartTime() int64 {
+	if x != nil {
+		return x.BaselineStartTime
+	}
+	return 0
+}
+
+func (x *GetAnomaliesResponse) GetBaselineEndTime() int64 {
+	if x != nil {
+		return x.BaselineEndTime
+	}
+	return 0
+}
+
+// Geocoding messages
+type Place struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	GeonameId   int64                  `protobuf:"varint,1,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CountryCode string                 `protobuf:"bytes,3,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// First-level administrative division code, e.g. "07" for Kharkiv Oblast
+	AdministrativeAreaCode string `protobuf:"bytes,4,opt,name=administrative_area_code,json=administrativeAreaCode,proto3" json:"administrative_area_code,omitempty"`
+	// GeoNames feature class and code, e.g. "P.PPLA"
+	FeatureCode string  `protobuf:"bytes,5,opt,name=feature_code,json=featureCode,proto3" json:"feature_code,omitempty"`
+	Latitude    float64 `protobuf:"fixed64,6,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude   float64 `protobuf:"fixed64,7,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Population  int64   `protobuf:"varint,8,opt,name=population,proto3" json:"population,omitempty"`
+	// Approximate extent, usable as the bbox filter of GetEvents
+	Bbox          *BoundingBox `protobuf:"bytes,9,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Place) Reset() {
+	*x = Place{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Place) ProtoMessage() {}
+
+func (x *Place) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Place.ProtoReflect.Descriptor instead.
+func (*Place) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Place) GetGeonameId() int64 {
+	if x != nil {
+		return x.GeonameId
+	}
+	return 0
+}
+
+func (x *Place) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Place) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *Place) GetAdministrativeAreaCode() string {
+	if x != nil {
+		return x.AdministrativeAreaCode
+	}
+	return ""
+}
+
+func (x *Place) GetFeatureCode() string {
+	if x != nil {
+		return x.FeatureCode
+	}
+	return ""
+}
+
+func (x *Place) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Place) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Place) GetPopulation() int64 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *Place) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+type GeocodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Restrict results to an ISO 3166-1 alpha-2 country code
+	CountryCode string `protobuf:"bytes,2,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// Maximum number of places to return, defaults to 10
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeocodeRequest) Reset() {
+	*x = GeocodeRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeocodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeocodeRequest) ProtoMessage() {}
+
+func (x *GeocodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeocodeRequest.ProtoReflect.Descriptor instead.
+func (*GeocodeRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GeocodeRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GeocodeRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *GeocodeRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GeocodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Places        []*Place               `protobuf:"bytes,1,rep,name=places,proto3" json:"places,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeocodeResponse) Reset() {
+	*x = GeocodeResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeocodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeocodeResponse) ProtoMessage() {}
+
+func (x *GeocodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeocodeResponse.ProtoReflect.Descriptor instead.
+func (*GeocodeResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GeocodeResponse) GetPlaces() []*Place {
+	if x != nil {
+		return x.Places
+	}
+	return nil
+}
+
+// Admin messages
+type BackfillEventLocationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of candidate documents scanned per call, defaults to 500
+	BatchSize int32 `protobuf:"varint,1,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Count the documents that would change without writing them
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// next_page_token of the previous call, empty to start over
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackfillEventLocationsRequest) Reset() {
+	*x = BackfillEventLocationsRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillEventLocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillEventLocationsRequest) ProtoMessage() {}
+
+func (x *BackfillEventLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillEventLocationsRequest.ProtoReflect.Descriptor instead.
+func (*BackfillEventLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BackfillEventLocationsRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *BackfillEventLocationsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *BackfillEventLocationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type BackfillEventLocationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Events with coordinates and a missing country code or administrative area
+	Scanned int64 `protobuf:"varint,1,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	Updated int64 `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Set when more candidates remain, to be passed as page_token
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackfillEventLocationsResponse) Reset() {
+	*x = BackfillEventLocationsResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillEventLocationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillEventLocationsResponse) ProtoMessage() {}
+
+func (x *BackfillEventLocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillEventLocationsResponse.ProtoReflect.Descriptor instead.
+func (*BackfillEventLocationsResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BackfillEventLocationsResponse) GetScanned() int64 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+func (x *BackfillEventLocationsResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *BackfillEventLocationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ImportEventsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format ImportFormat           `protobuf:"varint,1,opt,name=format,proto3,enum=geovision.v1.ImportFormat" json:"format,omitempty"`
+	// Contents of the file to import, which must fit in one request of up to
+	// the max_message_size of the server, 64 MiB by default
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Number of events inserted per batch, defaults to 500
+	BatchSize int32 `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Parse and de-duplicate without inserting anything
+	DryRun        bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEventsRequest) Reset() {
+	*x = ImportEventsRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEventsRequest) ProtoMessage() {}
+
+func (x *ImportEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEventsRequest.ProtoReflect.Descriptor instead.
+func (*ImportEventsRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportEventsRequest) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_IMPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportEventsRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportEventsRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *ImportEventsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int64                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ImportRowError) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportEventsProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Running totals; imported counts the events that would be inserted in a
+	// dry run
+	Rows       int64 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Imported   int64 `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	Duplicates int64 `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Failed     int64 `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Rows rejected since the previous progress message
+	Errors []*ImportRowError `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	// Set on the last message
+	Done          bool `protobuf:"varint,6,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEventsProgress) Reset() {
+	*x = ImportEventsProgress{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEventsProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEventsProgress) ProtoMessage() {}
+
+func (x *ImportEventsProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEventsProgress.ProtoReflect.Descriptor instead.
+func (*ImportEventsProgress) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ImportEventsProgress) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *ImportEventsProgress) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportEventsProgress) GetDuplicates() int64 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *ImportEventsProgress) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportEventsProgress) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportEventsProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// Names of the feature properties that fill each event field; unset names
+// default to the field name
+type GeoJSONPropertyMapping struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Property identifying the event across imports; defaults to the feature id
+	Key         string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Title       string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Unix seconds, an RFC 3339 timestamp or a date
+	HappenedAt string `protobuf:"bytes,4,opt,name=happened_at,json=happenedAt,proto3" json:"happened_at,omitempty"`
+	// Array of strings or a comma separated string
+	Tags string `protobuf:"bytes,5,opt,name=tags,proto3" json:"tags,omitempty"`
+	// Sensitivity name such as CONFIDENTIAL, or its number
+	Sensitivity   string `protobuf:"bytes,6,opt,name=sensitivity,proto3" json:"sensitivity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoJSONPropertyMapping) Reset() {
+	*x = GeoJSONPropertyMapping{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoJSONPropertyMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoJSONPropertyMapping) ProtoMessage() {}
+
+func (x *GeoJSONPropertyMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoJSONPropertyMapping.ProtoReflect.Descriptor instead.
+func (*GeoJSONPropertyMapping) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GeoJSONPropertyMapping) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GeoJSONPropertyMapping) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GeoJSONPropertyMapping) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GeoJSONPropertyMapping) GetHappenedAt() string {
+	if x != nil {
+		return x.HappenedAt
+	}
+	return ""
+}
+
+func (x *GeoJSONPropertyMapping) GetTags() string {
+	if x != nil {
+		return x.Tags
+	}
+	return ""
+}
+
+func (x *GeoJSONPropertyMapping) GetSensitivity() string {
+	if x != nil {
+		return x.Sensitivity
+	}
+	return ""
+}
+
+type ImportGeoJSONRequest struct {
+	state             protoimpl.MessageState  `protogen:"open.v1"`
+	FeatureCollection *structpb.Struct        `protobuf:"bytes,1,opt,name=feature_collection,json=featureCollection,proto3" json:"feature_collection,omitempty"`
+	Mapping           *GeoJSONPropertyMapping `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	// Number of events inserted per batch, defaults to 500
+	BatchSize int32 `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Validate without inserting anything
+	DryRun        bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportGeoJSONRequest) Reset() {
+	*x = ImportGeoJSONRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportGeoJSONRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportGeoJSONRequest) ProtoMessage() {}
+
+func (x *ImportGeoJSONRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportGeoJSONRequest.ProtoReflect.Descriptor instead.
+func (*ImportGeoJSONRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportGeoJSONRequest) GetFeatureCollection() *structpb.Struct {
+	if x != nil {
+		return x.FeatureCollection
+	}
+	return nil
+}
+
+func (x *ImportGeoJSONRequest) GetMapping() *GeoJSONPropertyMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *ImportGeoJSONRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *ImportGeoJSONRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type FeatureError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the feature in the collection
+	Index         int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureError) Reset() {
+	*x = FeatureError{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureError) ProtoMessage() {}
+
+func (x *FeatureError) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureError.ProtoReflect.Descriptor instead.
+func (*FeatureError) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FeatureError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FeatureError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportGeoJSONResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Features int64                  `protobuf:"varint,1,opt,name=features,proto3" json:"features,omitempty"`
+	// Events inserted, or that would be inserted in a dry run
+	Imported      int64           `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	Duplicates    int64           `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Failed        int64           `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*FeatureError `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportGeoJSONResponse) Reset() {
+	*x = ImportGeoJSONResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportGeoJSONResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportGeoJSONResponse) ProtoMessage() {}
+
+func (x *ImportGeoJSONResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportGeoJSONResponse.ProtoReflect.Descriptor instead.
+func (*ImportGeoJSONResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportGeoJSONResponse) GetFeatures() int64 {
+	if x != nil {
+		return x.Features
+	}
+	return 0
+}
+
+func (x *ImportGeoJSONResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportGeoJSONResponse) GetDuplicates() int64 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *ImportGeoJSONResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportGeoJSONResponse) GetErrors() []*FeatureError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ImportGpxRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Contents of the GPX file
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Track points closer than this to the simplified track are dropped,
+	// defaults to 10 meters
+	ToleranceMeters float64 `protobuf:"fixed64,2,opt,name=tolerance_meters,json=toleranceMeters,proto3" json:"tolerance_meters,omitempty"`
+	// Tags added to every event
+	Tags        []string       `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Sensitivity v1.Sensitivity `protobuf:"varint,4,opt,name=sensitivity,proto3,enum=model.v1.Sensitivity" json:"sensitivity,omitempty"`
+	// Parse and simplify without inserting anything
+	DryRun        bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportGpxRequest) Reset() {
+	*x = ImportGpxRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportGpxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportGpxRequest) ProtoMessage() {}
+
+func (x *ImportGpxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportGpxRequest.ProtoReflect.Descriptor instead.
+func (*ImportGpxRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportGpxRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportGpxRequest) GetToleranceMeters() float64 {
+	if x != nil {
+		return x.ToleranceMeters
+	}
+	return 0
+}
+
+func (x *ImportGpxRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ImportGpxRequest) GetSensitivity() v1.Sensitivity {
+	if x != nil {
+		return x.Sensitivity
+	}
+	return v1.Sensitivity(0)
+}
+
+func (x *ImportGpxRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportGpxResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Track points in the file and those kept after simplification
+	TrackPoints int64 `protobuf:"varint,1,opt,name=track_points,json=trackPoints,proto3" json:"track_points,omitempty"`
+	KeptPoints  int64 `protobuf:"varint,2,opt,name=kept_points,json=keptPoints,proto3" json:"kept_points,omitempty"`
+	Waypoints   int64 `protobuf:"varint,3,opt,name=waypoints,proto3" json:"waypoints,omitempty"`
+	// Events and relations inserted, or that would be inserted in a dry run
+	EventsCreated    int64 `protobuf:"varint,4,opt,name=events_created,json=eventsCreated,proto3" json:"events_created,omitempty"`
+	RelationsCreated int64 `protobuf:"varint,5,opt,name=relations_created,json=relationsCreated,proto3" json:"relations_created,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImportGpxResponse) Reset() {
+	*x = ImportGpxResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportGpxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportGpxResponse) ProtoMessage() {}
+
+func (x *ImportGpxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportGpxResponse.ProtoReflect.Descriptor instead.
+func (*ImportGpxResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportGpxResponse) GetTrackPoints() int64 {
+	if x != nil {
+		return x.TrackPoints
+	}
+	return 0
+}
+
+func (x *ImportGpxResponse) GetKeptPoints() int64 {
+	if x != nil {
+		return x.KeptPoints
+	}
+	return 0
+}
+
+func (x *ImportGpxResponse) GetWaypoints() int64 {
+	if x != nil {
+		return x.Waypoints
+	}
+	return 0
+}
+
+func (x *ImportGpxResponse) GetEventsCreated() int64 {
+	if x != nil {
+		return x.EventsCreated
+	}
+	return 0
+}
+
+func (x *ImportGpxResponse) GetRelationsCreated() int64 {
+	if x != nil {
+		return x.RelationsCreated
+	}
+	return 0
+}
+
+type GetKeyEntitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same filters as GetEventsRequest
+	StartTime int64        `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   int64        `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Bbox      *BoundingBox `protobuf:"bytes,3,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	// Number of entities to return, defaults to 10
+	Limit         int32             `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	RankBy        CentralityMeasure `protobuf:"varint,5,opt,name=rank_by,json=rankBy,proto3,enum=geovision.v1.CentralityMeasure" json:"rank_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyEntitiesRequest) Reset() {
+	*x = GetKeyEntitiesRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyEntitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyEntitiesRequest) ProtoMessage() {}
+
+func (x *GetKeyEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetKeyEntitiesRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetKeyEntitiesRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetKeyEntitiesRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+func (x *GetKeyEntitiesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetKeyEntitiesRequest) GetRankBy() CentralityMeasure {
+	if x != nil {
+		return x.RankBy
+	}
+	return CentralityMeasure_CENTRALITY_MEASURE_UNSPECIFIED
+}
+
+type KeyEntity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Document ID such as persons/123
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Collection of the entity
+	Type  string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Label string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	// Number of distinct neighbours
+	Degree int64 `protobuf:"varint,4,opt,name=degree,proto3" json:"degree,omitempty"`
+	// Share of shortest paths between other nodes going through the entity
+	Betweenness   float64 `protobuf:"fixed64,5,opt,name=betweenness,proto3" json:"betweenness,omitempty"`
+	Pagerank      float64 `protobuf:"fixed64,6,opt,name=pagerank,proto3" json:"pagerank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyEntity) Reset() {
+	*x = KeyEntity{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyEntity) ProtoMessage() {}
+
+func (x *KeyEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyEntity.ProtoReflect.Descriptor instead.
+func (*KeyEntity) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *KeyEntity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeyEntity) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *KeyEntity) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *KeyEntity) GetDegree() int64 {
+	if x != nil {
+		return x.Degree
+	}
+	return 0
+}
+
+func (x *KeyEntity) GetBetweenness() float64 {
+	if x != nil {
+		return x.Betweenness
+	}
+	return 0
+}
+
+func (x *KeyEntity) GetPagerank() float64 {
+	if x != nil {
+		return x.Pagerank
+	}
+	return 0
+}
+
+type GetKeyEntitiesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Entities []*KeyEntity           `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	// Size of the graph the scores were computed on
+	Nodes         int64 `protobuf:"varint,2,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         int64 `protobuf:"varint,3,opt,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyEntitiesResponse) Reset() {
+	*x = GetKeyEntitiesResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyEntitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyEntitiesResponse) ProtoMessage() {}
+
+func (x *GetKeyEntitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyEntitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyEntitiesResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetKeyEntitiesResponse) GetEntities() []*KeyEntity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *GetKeyEntitiesResponse) GetNodes() int64 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *GetKeyEntitiesResponse) GetEdges() int64 {
+	if x != nil {
+		return x.Edges
+	}
+	return 0
+}
+
+type DetectCommunitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same filters as GetEventsRequest
+	StartTime int64        `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   int64        `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Bbox      *BoundingBox `protobuf:"bytes,3,opt,name=bbox,proto3" json:"bbox,omitempty"`
+	// Number of relations to follow from the matching events, in either
+	// direction; defaults to 1
+	Depth         int32 `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectCommunitiesRequest) Reset() {
+	*x = DetectCommunitiesRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectCommunitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectCommunitiesRequest) ProtoMessage() {}
+
+func (x *DetectCommunitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectCommunitiesRequest.ProtoReflect.Descriptor instead.
+func (*DetectCommunitiesRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DetectCommunitiesRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *DetectCommunitiesRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *DetectCommunitiesRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+func (x *DetectCommunitiesRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type CommunityNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Document ID such as persons/123
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Collection of the node
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Label         string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Community     int32  `protobuf:"varint,4,opt,name=community,proto3" json:"community,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommunityNode) Reset() {
+	*x = CommunityNode{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommunityNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommunityNode) ProtoMessage() {}
+
+func (x *CommunityNode) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommunityNode.ProtoReflect.Descriptor instead.
+func (*CommunityNode) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CommunityNode) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CommunityNode) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CommunityNode) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *CommunityNode) GetCommunity() int32 {
+	if x != nil {
+		return x.Community
+	}
+	return 0
+}
+
+type Community struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Communities are numbered from 0 by decreasing size
+	Id   int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Number of events among the nodes
+	Events int64 `protobuf:"varint,3,opt,name=events,proto3" json:"events,omitempty"`
+	// Entities with the most relations inside the community, at most three
+	Representatives []*CommunityNode `protobuf:"bytes,4,rep,name=representatives,proto3" json:"representatives,omitempty"`
+	// Area covering the located nodes, unset when none has a location
+	Extent        *BoundingBox `protobuf:"bytes,5,opt,name=extent,proto3" json:"extent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Community) Reset() {
+	*x = Community{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Community) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Community) ProtoMessage() {}
+
+func (x *Community) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Community.ProtoReflect.Descriptor instead.
+func (*Community) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Community) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Community) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Community) GetEvents() int64 {
+	if x != nil {
+		return x.Events
+	}
+	return 0
+}
+
+func (x *Community) GetRepresentatives() []*CommunityNode {
+	if x != nil {
+		return x.Representatives
+	}
+	return nil
+}
+
+func (x *Community) GetExtent() *BoundingBox {
+	if x != nil {
+		return x.Extent
+	}
+	return nil
+}
+
+type DetectCommunitiesResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Communities []*Community           `protobuf:"bytes,1,rep,name=communities,proto3" json:"communities,omitempty"`
+	Nodes       []*CommunityNode       `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// Modularity of the partition, higher when communities are denser
+	Modularity    float64 `protobuf:"fixed64,3,opt,name=modularity,proto3" json:"modularity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEventsRequest) Reset() {
-	*x = GetEventsRequest{}
-	mi := &file_geovision_v1_event_service_proto_msgTypes[0]
+func (x *DetectCommunitiesResponse) Reset() {
+	*x = DetectCommunitiesResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventsRequest) String() string {
+func (x *DetectCommunitiesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventsRequest) ProtoMessage() {}
+func (*DetectCommunitiesResponse) ProtoMessage() {}
 
-func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_geovision_v1_event_service_proto_msgTypes[0]
+func (x *DetectCommunitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,48 +3076,54 @@ func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
-func (*GetEventsRequest) Descriptor() ([]byte, []int) {
-	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use DetectCommunitiesResponse.ProtoReflect.Descriptor instead.
+func (*DetectCommunitiesResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *GetEventsRequest) GetStartTime() int64 {
+func (x *DetectCommunitiesResponse) GetCommunities() []*Community {
 	if x != nil {
-		return x.StartTime
+		return x.Communities
 	}
-	return 0
+	return nil
 }
 
-func (x *GetEventsRequest) GetEndTime() int64 {
+func (x *DetectCommunitiesResponse) GetNodes() []*CommunityNode {
 	if x != nil {
-		return x.EndTime
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *DetectCommunitiesResponse) GetModularity() float64 {
+	if x != nil {
+		return x.Modularity
 	}
 	return 0
 }
 
-type GetEventsResponse struct {
+// Quota messages
+type GetQuotaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Relations     []*v1.Relation         `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty"`
-	Events        []*v1.Event            `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEventsResponse) Reset() {
-	*x = GetEventsResponse{}
-	mi := &file_geovision_v1_event_service_proto_msgTypes[1]
+func (x *GetQuotaRequest) Reset() {
+	*x = GetQuotaRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventsResponse) String() string {
+func (x *GetQuotaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventsResponse) ProtoMessage() {}
+func (*GetQuotaRequest) ProtoMessage() {}
 
-func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_geovision_v1_event_service_proto_msgTypes[1]
+func (x *GetQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -110,47 +3134,109 @@ func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
-func (*GetEventsResponse) Descriptor() ([]byte, []int) {
-	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetEventsResponse) GetRelations() []*v1.Relation {
+type Quota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rpc   string                 `protobuf:"bytes,1,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	// Requests per second refilling the bucket
+	Rate float64 `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Requests that may be made at once when the bucket is full
+	Burst int32 `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"`
+	// Requests that may be made right now
+	Remaining     float64 `protobuf:"fixed64,4,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[41]
 	if x != nil {
-		return x.Relations
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetEventsResponse) GetEvents() []*v1.Event {
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Quota) GetRpc() string {
 	if x != nil {
-		return x.Events
+		return x.Rpc
 	}
-	return nil
+	return ""
 }
 
-type GetEventRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+func (x *Quota) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *Quota) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *Quota) GetRemaining() float64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+// Empty when rate limiting is off
+type GetQuotaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whom the limits apply to: user:<subject> for authenticated callers,
+	// ip:<address> for the others
+	Caller string `protobuf:"bytes,1,opt,name=caller,proto3" json:"caller,omitempty"`
+	// One quota per limited RPC
+	Quotas        []*Quota `protobuf:"bytes,2,rep,name=quotas,proto3" json:"quotas,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEventRequest) Reset() {
-	*x = GetEventRequest{}
-	mi := &file_geovision_v1_event_service_proto_msgTypes[2]
+func (x *GetQuotaResponse) Reset() {
+	*x = GetQuotaResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventRequest) String() string {
+func (x *GetQuotaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventRequest) ProtoMessage() {}
+func (*GetQuotaResponse) ProtoMessage() {}
 
-func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_geovision_v1_event_service_proto_msgTypes[2]
+func (x *GetQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,40 +3247,68 @@ func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
-func (*GetEventRequest) Descriptor() ([]byte, []int) {
-	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *GetEventRequest) GetKey() string {
+func (x *GetQuotaResponse) GetCaller() string {
 	if x != nil {
-		return x.Key
+		return x.Caller
 	}
 	return ""
 }
 
-type GetEventResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Event         *v1.Event              `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+func (x *GetQuotaResponse) GetQuotas() []*Quota {
+	if x != nil {
+		return x.Quotas
+	}
+	return nil
+}
+
+// Audit messages
+type AuditEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unix seconds of the call
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Identity of the caller, set only when its token or certificate was
+	// verified
+	Subject  string   `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Username string   `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Roles    []string `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	// Name of the RPC, such as GetEvents
+	Rpc string `protobuf:"bytes,5,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	// Fields set in the request, without file contents
+	Filters *structpb.Struct `protobuf:"bytes,6,opt,name=filters,proto3" json:"filters,omitempty"`
+	// Items returned, such as events and relations
+	Results int64 `protobuf:"varint,7,opt,name=results,proto3" json:"results,omitempty"`
+	// Highest sensitivity among the items returned
+	Sensitivity v1.Sensitivity `protobuf:"varint,8,opt,name=sensitivity,proto3,enum=model.v1.Sensitivity" json:"sensitivity,omitempty"`
+	// Status code of the call, such as OK or PermissionDenied
+	Code       string  `protobuf:"bytes,9,opt,name=code,proto3" json:"code,omitempty"`
+	DurationMs float64 `protobuf:"fixed64,10,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Whether the identity of the caller was verified; calls without it are
+	// recorded as unauthenticated, whatever their token claims
+	Authenticated bool `protobuf:"varint,11,opt,name=authenticated,proto3" json:"authenticated,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEventResponse) Reset() {
-	*x = GetEventResponse{}
-	mi := &file_geovision_v1_event_service_proto_msgTypes[3]
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventResponse) String() string {
+func (x *AuditEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventResponse) ProtoMessage() {}
+func (*AuditEntry) ProtoMessage() {}
 
-func (x *GetEventResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_geovision_v1_event_service_proto_msgTypes[3]
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,40 +3319,118 @@ func (x *GetEventResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventResponse.ProtoReflect.Descriptor instead.
-func (*GetEventResponse) Descriptor() ([]byte, []int) {
-	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *GetEventResponse) GetEvent() *v1.Event {
+func (x *AuditEntry) GetTime() int64 {
 	if x != nil {
-		return x.Event
+		return x.Time
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetRoles() []string {
+	if x != nil {
+		return x.Roles
 	}
 	return nil
 }
 
-type GetEventRelatedEntitiesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+func (x *AuditEntry) GetRpc() string {
+	if x != nil {
+		return x.Rpc
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetFilters() *structpb.Struct {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetResults() int64 {
+	if x != nil {
+		return x.Results
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetSensitivity() v1.Sensitivity {
+	if x != nil {
+		return x.Sensitivity
+	}
+	return v1.Sensitivity(0)
+}
+
+func (x *AuditEntry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDurationMs() float64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetAuthenticated() bool {
+	if x != nil {
+		return x.Authenticated
+	}
+	return false
+}
+
+type QueryAuditLogRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Subject string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Rpc     string                 `protobuf:"bytes,2,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	// Unix seconds bounding the time of the calls, each optional
+	StartTime int64 `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   int64 `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Only calls that returned items at least this sensitive
+	MinSensitivity v1.Sensitivity `protobuf:"varint,5,opt,name=min_sensitivity,json=minSensitivity,proto3,enum=model.v1.Sensitivity" json:"min_sensitivity,omitempty"`
+	// Maximum number of entries to return, defaults to 100
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEventRelatedEntitiesRequest) Reset() {
-	*x = GetEventRelatedEntitiesRequest{}
-	mi := &file_geovision_v1_event_service_proto_msgTypes[4]
+func (x *QueryAuditLogRequest) Reset() {
+	*x = QueryAuditLogRequest{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventRelatedEntitiesRequest) String() string {
+func (x *QueryAuditLogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventRelatedEntitiesRequest) ProtoMessage() {}
+func (*QueryAuditLogRequest) ProtoMessage() {}
 
-func (x *GetEventRelatedEntitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_geovision_v1_event_service_proto_msgTypes[4]
+func (x *QueryAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,40 +3441,75 @@ func (x *GetEventRelatedEntitiesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventRelatedEntitiesRequest.ProtoReflect.Descriptor instead.
-func (*GetEventRelatedEntitiesRequest) Descriptor() ([]byte, []int) {
-	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use QueryAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *GetEventRelatedEntitiesRequest) GetKey() string {
+func (x *QueryAuditLogRequest) GetSubject() string {
 	if x != nil {
-		return x.Key
+		return x.Subject
 	}
 	return ""
 }
 
-type GetEventRelatedEntitiesResponse struct {
+func (x *QueryAuditLogRequest) GetRpc() string {
+	if x != nil {
+		return x.Rpc
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *QueryAuditLogRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *QueryAuditLogRequest) GetMinSensitivity() v1.Sensitivity {
+	if x != nil {
+		return x.MinSensitivity
+	}
+	return v1.Sensitivity(0)
+}
+
+func (x *QueryAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryAuditLogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entities      []*v1.RelatedEntity    `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEventRelatedEntitiesResponse) Reset() {
-	*x = GetEventRelatedEntitiesResponse{}
-	mi := &file_geovision_v1_event_service_proto_msgTypes[5]
+func (x *QueryAuditLogResponse) Reset() {
+	*x = QueryAuditLogResponse{}
+	mi := &file_geovision_v1_event_service_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventRelatedEntitiesResponse) String() string {
+func (x *QueryAuditLogResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventRelatedEntitiesResponse) ProtoMessage() {}
+func (*QueryAuditLogResponse) ProtoMessage() {}
 
-func (x *GetEventRelatedEntitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_geovision_v1_event_service_proto_msgTypes[5]
+func (x *QueryAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_geovision_v1_event_service_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,14 +3520,14 @@ func (x *GetEventRelatedEntitiesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventRelatedEntitiesResponse.ProtoReflect.Descriptor instead.
-func (*GetEventRelatedEntitiesResponse) Descriptor() ([]byte, []int) {
-	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use QueryAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_geovision_v1_event_service_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetEventRelatedEntitiesResponse) GetEntities() []*v1.RelatedEntity {
+func (x *QueryAuditLogResponse) GetEntries() []*AuditEntry {
 	if x != nil {
-		return x.Entities
+		return x.Entries
 	}
 	return nil
 }
@@ -309,14 +3536,35 @@ var File_geovision_v1_event_service_proto protoreflect.FileDescriptor
 
 const file_geovision_v1_event_service_proto_rawDesc = "" +
 	"\n" +
-	" geovision/v1/event_service.proto\x12\fgeovision.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x14model/v1/osint.proto\x1a\x16model/v1/related.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"L\n" +
+	" geovision/v1/event_service.proto\x12\fgeovision.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x14model/v1/osint.proto\x1a\x16model/v1/related.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"{\n" +
 	"\x10GetEventsRequest\x12\x1d\n" +
 	"\n" +
 	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
-	"\bend_time\x18\x02 \x01(\x03R\aendTime\"n\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\x12-\n" +
+	"\x04bbox\x18\x03 \x01(\v2\x19.geovision.v1.BoundingBoxR\x04bbox\"n\n" +
 	"\x11GetEventsResponse\x120\n" +
 	"\trelations\x18\x01 \x03(\v2\x12.model.v1.RelationR\trelations\x12'\n" +
-	"\x06events\x18\x02 \x03(\v2\x0f.model.v1.EventR\x06events\"#\n" +
+	"\x06events\x18\x02 \x03(\v2\x0f.model.v1.EventR\x06events\"\xe0\x01\n" +
+	"\x13ExportEventsRequest\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\x12-\n" +
+	"\x04bbox\x18\x03 \x01(\v2\x19.geovision.v1.BoundingBoxR\x04bbox\x122\n" +
+	"\x06format\x18\x04 \x01(\x0e2\x1a.geovision.v1.ExportFormatR\x06format\x12\x18\n" +
+	"\acolumns\x18\x05 \x03(\tR\acolumns\x12\x12\n" +
+	"\x04gzip\x18\x06 \x01(\bR\x04gzip\"|\n" +
+	"\x11ExportStixRequest\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\x12-\n" +
+	"\x04bbox\x18\x03 \x01(\v2\x19.geovision.v1.BoundingBoxR\x04bbox\"\xc6\x01\n" +
+	"\x12ExportGraphRequest\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\x12-\n" +
+	"\x04bbox\x18\x03 \x01(\v2\x19.geovision.v1.BoundingBoxR\x04bbox\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x121\n" +
+	"\x06format\x18\x05 \x01(\x0e2\x19.geovision.v1.GraphFormatR\x06format\"#\n" +
 	"\x0fGetEventRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\"9\n" +
 	"\x10GetEventResponse\x12%\n" +
@@ -324,12 +3572,270 @@ const file_geovision_v1_event_service_proto_rawDesc = "" +
 	"\x1eGetEventRelatedEntitiesRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\"V\n" +
 	"\x1fGetEventRelatedEntitiesResponse\x123\n" +
-	"\bentities\x18\x01 \x03(\v2\x17.model.v1.RelatedEntityR\bentities2\x92\x02\n" +
+	"\bentities\x18\x01 \x03(\v2\x17.model.v1.RelatedEntityR\bentities\"\x9d\x01\n" +
+	"\vBoundingBox\x12!\n" +
+	"\fmin_latitude\x18\x01 \x01(\x01R\vminLatitude\x12#\n" +
+	"\rmin_longitude\x18\x02 \x01(\x01R\fminLongitude\x12!\n" +
+	"\fmax_latitude\x18\x03 \x01(\x01R\vmaxLatitude\x12#\n" +
+	"\rmax_longitude\x18\x04 \x01(\x01R\fmaxLongitude\"+\n" +
+	"\x19GetEntityFootprintRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xdf\x01\n" +
+	"\x1aGetEntityFootprintResponse\x12'\n" +
+	"\x06events\x18\x01 \x03(\v2\x0f.model.v1.EventR\x06events\x12-\n" +
+	"\x05track\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05track\x12\x1d\n" +
+	"\n" +
+	"first_seen\x18\x03 \x01(\x03R\tfirstSeen\x12\x1b\n" +
+	"\tlast_seen\x18\x04 \x01(\x03R\blastSeen\x12-\n" +
+	"\x04bbox\x18\x05 \x01(\v2\x19.geovision.v1.BoundingBoxR\x04bbox\"\xc2\x01\n" +
+	"\x1cFindCoLocatedEntitiesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\x12%\n" +
+	"\x0etime_tolerance\x18\x03 \x01(\x03R\rtimeTolerance\x12,\n" +
+	"\x12min_co_occurrences\x18\x04 \x01(\x05R\x10minCoOccurrences\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\xaf\x01\n" +
+	"\n" +
+	"CoLocation\x122\n" +
+	"\ftarget_event\x18\x01 \x01(\v2\x0f.model.v1.EventR\vtargetEvent\x12%\n" +
+	"\x05event\x18\x02 \x01(\v2\x0f.model.v1.EventR\x05event\x12'\n" +
+	"\x0fdistance_meters\x18\x03 \x01(\x01R\x0edistanceMeters\x12\x1d\n" +
+	"\n" +
+	"time_delta\x18\x04 \x01(\x03R\ttimeDelta\"\xa6\x01\n" +
+	"\x0fCoLocatedEntity\x12/\n" +
+	"\x06entity\x18\x01 \x01(\v2\x17.model.v1.RelatedEntityR\x06entity\x12%\n" +
+	"\x0eco_occurrences\x18\x02 \x01(\x05R\rcoOccurrences\x12;\n" +
+	"\fco_locations\x18\x03 \x03(\v2\x18.geovision.v1.CoLocationR\vcoLocations\"Z\n" +
+	"\x1dFindCoLocatedEntitiesResponse\x129\n" +
+	"\bentities\x18\x01 \x03(\v2\x1d.geovision.v1.CoLocatedEntityR\bentities\"\x8c\x02\n" +
+	"\x13GetAnomaliesRequest\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\x12E\n" +
+	"\x0fregion_grouping\x18\x03 \x01(\x0e2\x1c.geovision.v1.RegionGroupingR\x0eregionGrouping\x12+\n" +
+	"\x11geohash_precision\x18\x04 \x01(\x05R\x10geohashPrecision\x12)\n" +
+	"\x10baseline_windows\x18\x05 \x01(\x05R\x0fbaselineWindows\x12\x1c\n" +
+	"\tthreshold\x18\x06 \x01(\x01R\tthreshold\"\x80\x02\n" +
+	"\aAnomaly\x12<\n" +
+	"\tdimension\x18\x01 \x01(\x0e2\x1e.geovision.v1.AnomalyDimensionR\tdimension\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12<\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x1e.geovision.v1.AnomalyDirectionR\tdirection\x12\x1a\n" +
+	"\bobserved\x18\x04 \x01(\x03R\bobserved\x12\x1a\n" +
+	"\bexpected\x18\x05 \x01(\x01R\bexpected\x12\x16\n" +
+	"\x06stddev\x18\x06 \x01(\x01R\x06stddev\x12\x17\n" +
+	"\az_score\x18\a \x01(\x01R\x06zScore\"\xa7\x01\n" +
+	"\x14GetAnomaliesResponse\x123\n" +
+	"\tanomalies\x18\x01 \x03(\v2\x15.geovision.v1.AnomalyR\tanomalies\x12.\n" +
+	"\x13baseline_start_time\x18\x02 \x01(\x03R\x11baselineStartTime\x12*\n" +
+	"\x11baseline_end_time\x18\x03 \x01(\x03R\x0fbaselineEndTime\"\xc3\x02\n" +
+	"\x05Place\x12\x1d\n" +
+	"\n" +
+	"geoname_id\x18\x01 \x01(\x03R\tgeonameId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\x128\n" +
+	"\x18administrative_area_code\x18\x04 \x01(\tR\x16administrativeAreaCode\x12!\n" +
+	"\ffeature_code\x18\x05 \x01(\tR\vfeatureCode\x12\x1a\n" +
+	"\blatitude\x18\x06 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\a \x01(\x01R\tlongitude\x12\x1e\n" +
+	"\n" +
+	"population\x18\b \x01(\x03R\n" +
+	"population\x12-\n" +
+	"\x04bbox\x18\t \x01(\v2\x19.geovision.v1.BoundingBoxR\x04bbox\"_\n" +
+	"\x0eGeocodeRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12!\n" +
+	"\fcountry_code\x18\x02 \x01(\tR\vcountryCode\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\">\n" +
+	"\x0fGeocodeResponse\x12+\n" +
+	"\x06places\x18\x01 \x03(\v2\x13.geovision.v1.PlaceR\x06places\"v\n" +
+	"\x1dBackfillEventLocationsRequest\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x01 \x01(\x05R\tbatchSize\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"|\n" +
+	"\x1eBackfillEventLocationsResponse\x12\x18\n" +
+	"\ascanned\x18\x01 \x01(\x03R\ascanned\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x03R\aupdated\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\x95\x01\n" +
+	"\x13ImportEventsRequest\x122\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x1a.geovision.v1.ImportFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x05R\tbatchSize\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\">\n" +
+	"\x0eImportRowError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xc8\x01\n" +
+	"\x14ImportEventsProgress\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\x03R\x04rows\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x03R\bimported\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x03R\n" +
+	"duplicates\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x03R\x06failed\x124\n" +
+	"\x06errors\x18\x05 \x03(\v2\x1c.geovision.v1.ImportRowErrorR\x06errors\x12\x12\n" +
+	"\x04done\x18\x06 \x01(\bR\x04done\"\xb9\x01\n" +
+	"\x16GeoJSONPropertyMapping\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vhappened_at\x18\x04 \x01(\tR\n" +
+	"happenedAt\x12\x12\n" +
+	"\x04tags\x18\x05 \x01(\tR\x04tags\x12 \n" +
+	"\vsensitivity\x18\x06 \x01(\tR\vsensitivity\"\xd6\x01\n" +
+	"\x14ImportGeoJSONRequest\x12F\n" +
+	"\x12feature_collection\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x11featureCollection\x12>\n" +
+	"\amapping\x18\x02 \x01(\v2$.geovision.v1.GeoJSONPropertyMappingR\amapping\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x05R\tbatchSize\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\">\n" +
+	"\fFeatureError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xbb\x01\n" +
+	"\x15ImportGeoJSONResponse\x12\x1a\n" +
+	"\bfeatures\x18\x01 \x01(\x03R\bfeatures\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x03R\bimported\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x03R\n" +
+	"duplicates\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x03R\x06failed\x122\n" +
+	"\x06errors\x18\x05 \x03(\v2\x1a.geovision.v1.FeatureErrorR\x06errors\"\xb7\x01\n" +
+	"\x10ImportGpxRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12)\n" +
+	"\x10tolerance_meters\x18\x02 \x01(\x01R\x0ftoleranceMeters\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x127\n" +
+	"\vsensitivity\x18\x04 \x01(\x0e2\x15.model.v1.SensitivityR\vsensitivity\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"\xc9\x01\n" +
+	"\x11ImportGpxResponse\x12!\n" +
+	"\ftrack_points\x18\x01 \x01(\x03R\vtrackPoints\x12\x1f\n" +
+	"\vkept_points\x18\x02 \x01(\x03R\n" +
+	"keptPoints\x12\x1c\n" +
+	"\twaypoints\x18\x03 \x01(\x03R\twaypoints\x12%\n" +
+	"\x0eevents_created\x18\x04 \x01(\x03R\reventsCreated\x12+\n" +
+	"\x11relations_created\x18\x05 \x01(\x03R\x10relationsCreated\"\xd0\x01\n" +
+	"\x15GetKeyEntitiesRequest\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\x12-\n" +
+	"\x04bbox\x18\x03 \x01(\v2\x19.geovision.v1.BoundingBoxR\x04bbox\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x128\n" +
+	"\arank_by\x18\x05 \x01(\x0e2\x1f.geovision.v1.CentralityMeasureR\x06rankBy\"\x9b\x01\n" +
+	"\tKeyEntity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12\x16\n" +
+	"\x06degree\x18\x04 \x01(\x03R\x06degree\x12 \n" +
+	"\vbetweenness\x18\x05 \x01(\x01R\vbetweenness\x12\x1a\n" +
+	"\bpagerank\x18\x06 \x01(\x01R\bpagerank\"y\n" +
+	"\x16GetKeyEntitiesResponse\x123\n" +
+	"\bentities\x18\x01 \x03(\v2\x17.geovision.v1.KeyEntityR\bentities\x12\x14\n" +
+	"\x05nodes\x18\x02 \x01(\x03R\x05nodes\x12\x14\n" +
+	"\x05edges\x18\x03 \x01(\x03R\x05edges\"\x99\x01\n" +
+	"\x18DetectCommunitiesRequest\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\x12-\n" +
+	"\x04bbox\x18\x03 \x01(\v2\x19.geovision.v1.BoundingBoxR\x04bbox\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\"g\n" +
+	"\rCommunityNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12\x1c\n" +
+	"\tcommunity\x18\x04 \x01(\x05R\tcommunity\"\xc1\x01\n" +
+	"\tCommunity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06events\x18\x03 \x01(\x03R\x06events\x12E\n" +
+	"\x0frepresentatives\x18\x04 \x03(\v2\x1b.geovision.v1.CommunityNodeR\x0frepresentatives\x121\n" +
+	"\x06extent\x18\x05 \x01(\v2\x19.geovision.v1.BoundingBoxR\x06extent\"\xa9\x01\n" +
+	"\x19DetectCommunitiesResponse\x129\n" +
+	"\vcommunities\x18\x01 \x03(\v2\x17.geovision.v1.CommunityR\vcommunities\x121\n" +
+	"\x05nodes\x18\x02 \x03(\v2\x1b.geovision.v1.CommunityNodeR\x05nodes\x12\x1e\n" +
+	"\n" +
+	"modularity\x18\x03 \x01(\x01R\n" +
+	"modularity\"\x11\n" +
+	"\x0fGetQuotaRequest\"a\n" +
+	"\x05Quota\x12\x10\n" +
+	"\x03rpc\x18\x01 \x01(\tR\x03rpc\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x01R\x04rate\x12\x14\n" +
+	"\x05burst\x18\x03 \x01(\x05R\x05burst\x12\x1c\n" +
+	"\tremaining\x18\x04 \x01(\x01R\tremaining\"W\n" +
+	"\x10GetQuotaResponse\x12\x16\n" +
+	"\x06caller\x18\x01 \x01(\tR\x06caller\x12+\n" +
+	"\x06quotas\x18\x02 \x03(\v2\x13.geovision.v1.QuotaR\x06quotas\"\xdf\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12\x10\n" +
+	"\x03rpc\x18\x05 \x01(\tR\x03rpc\x121\n" +
+	"\afilters\x18\x06 \x01(\v2\x17.google.protobuf.StructR\afilters\x12\x18\n" +
+	"\aresults\x18\a \x01(\x03R\aresults\x127\n" +
+	"\vsensitivity\x18\b \x01(\x0e2\x15.model.v1.SensitivityR\vsensitivity\x12\x12\n" +
+	"\x04code\x18\t \x01(\tR\x04code\x12\x1f\n" +
+	"\vduration_ms\x18\n" +
+	" \x01(\x01R\n" +
+	"durationMs\x12$\n" +
+	"\rauthenticated\x18\v \x01(\bR\rauthenticated\"\xd2\x01\n" +
+	"\x14QueryAuditLogRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x10\n" +
+	"\x03rpc\x18\x02 \x01(\tR\x03rpc\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\x03R\aendTime\x12>\n" +
+	"\x0fmin_sensitivity\x18\x05 \x01(\x0e2\x15.model.v1.SensitivityR\x0eminSensitivity\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"K\n" +
+	"\x15QueryAuditLogResponse\x122\n" +
+	"\aentries\x18\x01 \x03(\v2\x18.geovision.v1.AuditEntryR\aentries*_\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\x01\x12\x19\n" +
+	"\x15EXPORT_FORMAT_PARQUET\x10\x02*\\\n" +
+	"\vGraphFormat\x12\x1c\n" +
+	"\x18GRAPH_FORMAT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14GRAPH_FORMAT_GRAPHML\x10\x01\x12\x15\n" +
+	"\x11GRAPH_FORMAT_GEXF\x10\x02*p\n" +
+	"\x0eRegionGrouping\x12\x1f\n" +
+	"\x1bREGION_GROUPING_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cREGION_GROUPING_COUNTRY_CODE\x10\x01\x12\x1b\n" +
+	"\x17REGION_GROUPING_GEOHASH\x10\x02*n\n" +
+	"\x10AnomalyDimension\x12!\n" +
+	"\x1dANOMALY_DIMENSION_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18ANOMALY_DIMENSION_REGION\x10\x01\x12\x19\n" +
+	"\x15ANOMALY_DIMENSION_TAG\x10\x02*n\n" +
+	"\x10AnomalyDirection\x12!\n" +
+	"\x1dANOMALY_DIRECTION_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ANOMALY_DIRECTION_SPIKE\x10\x01\x12\x1a\n" +
+	"\x16ANOMALY_DIRECTION_DROP\x10\x02*_\n" +
+	"\fImportFormat\x12\x1d\n" +
+	"\x19IMPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13IMPORT_FORMAT_ACLED\x10\x01\x12\x17\n" +
+	"\x13IMPORT_FORMAT_GDELT\x10\x02*\x9b\x01\n" +
+	"\x11CentralityMeasure\x12\"\n" +
+	"\x1eCENTRALITY_MEASURE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19CENTRALITY_MEASURE_DEGREE\x10\x01\x12\"\n" +
+	"\x1eCENTRALITY_MEASURE_BETWEENNESS\x10\x02\x12\x1f\n" +
+	"\x1bCENTRALITY_MEASURE_PAGERANK\x10\x032\xfa\x0f\n" +
 	"\n" +
 	"GeoService\x12`\n" +
 	"\tGetEvents\x12\x1e.geovision.v1.GetEventsRequest\x1a\x1f.geovision.v1.GetEventsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
 	"/v1/events\x12\xa1\x01\n" +
-	"\x17GetEventRelatedEntities\x12,.geovision.v1.GetEventRelatedEntitiesRequest\x1a-.geovision.v1.GetEventRelatedEntitiesResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/events/{key}/related-entitiesB\x8b\x02\x92A\xc1\x01\x12\x97\x01\n" +
+	"\x17GetEventRelatedEntities\x12,.geovision.v1.GetEventRelatedEntitiesRequest\x1a-.geovision.v1.GetEventRelatedEntitiesResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/events/{key}/related-entities\x12I\n" +
+	"\fExportEvents\x12!.geovision.v1.ExportEventsRequest\x1a\x14.google.api.HttpBody0\x01\x12c\n" +
+	"\n" +
+	"ExportStix\x12\x1f.geovision.v1.ExportStixRequest\x1a\x14.google.api.HttpBody\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/events/export.stix\x12E\n" +
+	"\vExportGraph\x12 .geovision.v1.ExportGraphRequest\x1a\x14.google.api.HttpBody\x12\x90\x01\n" +
+	"\x12GetEntityFootprint\x12'.geovision.v1.GetEntityFootprintRequest\x1a(.geovision.v1.GetEntityFootprintResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/entities/{id=*/*}/footprint\x12\x9a\x01\n" +
+	"\x15FindCoLocatedEntities\x12*.geovision.v1.FindCoLocatedEntitiesRequest\x1a+.geovision.v1.FindCoLocatedEntitiesResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/entities/{id=*/*}/co-located\x12u\n" +
+	"\x0eGetKeyEntities\x12#.geovision.v1.GetKeyEntitiesRequest\x1a$.geovision.v1.GetKeyEntitiesResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/entities/key\x12\x84\x01\n" +
+	"\x11DetectCommunities\x12&.geovision.v1.DetectCommunitiesRequest\x1a'.geovision.v1.DetectCommunitiesResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/events/communities\x12s\n" +
+	"\fGetAnomalies\x12!.geovision.v1.GetAnomaliesRequest\x1a\".geovision.v1.GetAnomaliesResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/events/anomalies\x12[\n" +
+	"\aGeocode\x12\x1c.geovision.v1.GeocodeRequest\x1a\x1d.geovision.v1.GeocodeResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/geocode\x12\xa3\x01\n" +
+	"\x16BackfillEventLocations\x12+.geovision.v1.BackfillEventLocationsRequest\x1a,.geovision.v1.BackfillEventLocationsResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/v1/admin/events/backfill-locations\x12{\n" +
+	"\fImportEvents\x12!.geovision.v1.ImportEventsRequest\x1a\".geovision.v1.ImportEventsProgress\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/admin/events/import0\x01\x12\x84\x01\n" +
+	"\rImportGeoJSON\x12\".geovision.v1.ImportGeoJSONRequest\x1a#.geovision.v1.ImportGeoJSONResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/admin/events/import-geojson\x12t\n" +
+	"\tImportGpx\x12\x1e.geovision.v1.ImportGpxRequest\x1a\x1f.geovision.v1.ImportGpxResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/admin/events/import-gpx\x12\\\n" +
+	"\bGetQuota\x12\x1d.geovision.v1.GetQuotaRequest\x1a\x1e.geovision.v1.GetQuotaResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/quota\x12q\n" +
+	"\rQueryAuditLog\x12\".geovision.v1.QueryAuditLogRequest\x1a#.geovision.v1.QueryAuditLogResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/admin/auditB\x8b\x02\x92A\xc1\x01\x12\x97\x01\n" +
 	"\rGeovision API\x122The Geovision API handles data for geovision uses.\"\v\n" +
 	"\tOmni Team*>\n" +
 	"\n" +
@@ -347,32 +3853,152 @@ func file_geovision_v1_event_service_proto_rawDescGZIP() []byte {
 	return file_geovision_v1_event_service_proto_rawDescData
 }
 
-var file_geovision_v1_event_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_geovision_v1_event_service_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_geovision_v1_event_service_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_geovision_v1_event_service_proto_goTypes = []any{
-	(*GetEventsRequest)(nil),                // 0: geovision.v1.GetEventsRequest
-	(*GetEventsResponse)(nil),               // 1: geovision.v1.GetEventsResponse
-	(*GetEventRequest)(nil),                 // 2: geovision.v1.GetEventRequest
-	(*GetEventResponse)(nil),                // 3: geovision.v1.GetEventResponse
-	(*GetEventRelatedEntitiesRequest)(nil),  // 4: geovision.v1.GetEventRelatedEntitiesRequest
-	(*GetEventRelatedEntitiesResponse)(nil), // 5: geovision.v1.GetEventRelatedEntitiesResponse
-	(*v1.Relation)(nil),                     // 6: model.v1.Relation
-	(*v1.Event)(nil),                        // 7: model.v1.Event
-	(*v1.RelatedEntity)(nil),                // 8: model.v1.RelatedEntity
+	(ExportFormat)(0),                       // 0: geovision.v1.ExportFormat
+	(GraphFormat)(0),                        // 1: geovision.v1.GraphFormat
+	(RegionGrouping)(0),                     // 2: geovision.v1.RegionGrouping
+	(AnomalyDimension)(0),                   // 3: geovision.v1.AnomalyDimension
+	(AnomalyDirection)(0),                   // 4: geovision.v1.AnomalyDirection
+	(ImportFormat)(0),                       // 5: geovision.v1.ImportFormat
+	(CentralityMeasure)(0),                  // 6: geovision.v1.CentralityMeasure
+	(*GetEventsRequest)(nil),                // 7: geovision.v1.GetEventsRequest
+	(*GetEventsResponse)(nil),               // 8: geovision.v1.GetEventsResponse
+	(*ExportEventsRequest)(nil),             // 9: geovision.v1.ExportEventsRequest
+	(*ExportStixRequest)(nil),               // 10: geovision.v1.ExportStixRequest
+	(*ExportGraphRequest)(nil),              // 11: geovision.v1.ExportGraphRequest
+	(*GetEventRequest)(nil),                 // 12: geovision.v1.GetEventRequest
+	(*GetEventResponse)(nil),                // 13: geovision.v1.GetEventResponse
+	(*GetEventRelatedEntitiesRequest)(nil),  // 14: geovision.v1.GetEventRelatedEntitiesRequest
+	(*GetEventRelatedEntitiesResponse)(nil), // 15: geovision.v1.GetEventRelatedEntitiesResponse
+	(*BoundingBox)(nil),                     // 16: geovision.v1.BoundingBox
+	(*GetEntityFootprintRequest)(nil),       // 17: geovision.v1.GetEntityFootprintRequest
+	(*GetEntityFootprintResponse)(nil),      // 18: geovision.v1.GetEntityFootprintResponse
+	(*FindCoLocatedEntitiesRequest)(nil),    // 19: geovision.v1.FindCoLocatedEntitiesRequest
+	(*CoLocation)(nil),                      // 20: geovision.v1.CoLocation
+	(*CoLocatedEntity)(nil),                 // 21: geovision.v1.CoLocatedEntity
+	(*FindCoLocatedEntitiesResponse)(nil),   // 22: geovision.v1.FindCoLocatedEntitiesResponse
+	(*GetAnomaliesRequest)(nil),             // 23: geovision.v1.GetAnomaliesRequest
+	(*Anomaly)(nil),                         // 24: geovision.v1.Anomaly
+	(*GetAnomaliesResponse)(nil),            // 25: geovision.v1.GetAnomaliesResponse
+	(*Place)(nil),                           // 26: geovision.v1.Place
+	(*GeocodeRequest)(nil),                  // 27: geovision.v1.GeocodeRequest
+	(*GeocodeResponse)(nil),                 // 28: geovision.v1.GeocodeResponse
+	(*BackfillEventLocationsRequest)(nil),   // 29: geovision.v1.BackfillEventLocationsRequest
+	(*BackfillEventLocationsResponse)(nil),  // 30: geovision.v1.BackfillEventLocationsResponse
+	(*ImportEventsRequest)(nil),             // 31: geovision.v1.ImportEventsRequest
+	(*ImportRowError)(nil),                  // 32: geovision.v1.ImportRowError
+	(*ImportEventsProgress)(nil),            // 33: geovision.v1.ImportEventsProgress
+	(*GeoJSONPropertyMapping)(nil),          // 34: geovision.v1.GeoJSONPropertyMapping
+	(*ImportGeoJSONRequest)(nil),            // 35: geovision.v1.ImportGeoJSONRequest
+	(*FeatureError)(nil),                    // 36: geovision.v1.FeatureError
+	(*ImportGeoJSONResponse)(nil),           // 37: geovision.v1.ImportGeoJSONResponse
+	(*ImportGpxRequest)(nil),                // 38: geovision.v1.ImportGpxRequest
+	(*ImportGpxResponse)(nil),               // 39: geovision.v1.ImportGpxResponse
+	(*GetKeyEntitiesRequest)(nil),           // 40: geovision.v1.GetKeyEntitiesRequest
+	(*KeyEntity)(nil),                       // 41: geovision.v1.KeyEntity
+	(*GetKeyEntitiesResponse)(nil),          // 42: geovision.v1.GetKeyEntitiesResponse
+	(*DetectCommunitiesRequest)(nil),        // 43: geovision.v1.DetectCommunitiesRequest
+	(*CommunityNode)(nil),                   // 44: geovision.v1.CommunityNode
+	(*Community)(nil),                       // 45: geovision.v1.Community
+	(*DetectCommunitiesResponse)(nil),       // 46: geovision.v1.DetectCommunitiesResponse
+	(*GetQuotaRequest)(nil),                 // 47: geovision.v1.GetQuotaRequest
+	(*Quota)(nil),                           // 48: geovision.v1.Quota
+	(*GetQuotaResponse)(nil),                // 49: geovision.v1.GetQuotaResponse
+	(*AuditEntry)(nil),                      // 50: geovision.v1.AuditEntry
+	(*QueryAuditLogRequest)(nil),            // 51: geovision.v1.QueryAuditLogRequest
+	(*QueryAuditLogResponse)(nil),           // 52: geovision.v1.QueryAuditLogResponse
+	(*v1.Relation)(nil),                     // 53: model.v1.Relation
+	(*v1.Event)(nil),                        // 54: model.v1.Event
+	(*v1.RelatedEntity)(nil),                // 55: model.v1.RelatedEntity
+	(*structpb.Struct)(nil),                 // 56: google.protobuf.Struct
+	(v1.Sensitivity)(0),                     // 57: model.v1.Sensitivity
+	(*httpbody.HttpBody)(nil),               // 58: google.api.HttpBody
 }
 var file_geovision_v1_event_service_proto_depIdxs = []int32{
-	6, // 0: geovision.v1.GetEventsResponse.relations:type_name -> model.v1.Relation
-	7, // 1: geovision.v1.GetEventsResponse.events:type_name -> model.v1.Event
-	7, // 2: geovision.v1.GetEventResponse.event:type_name -> model.v1.Event
-	8, // 3: geovision.v1.GetEventRelatedEntitiesResponse.entities:type_name -> model.v1.RelatedEntity
-	0, // 4: geovision.v1.GeoService.GetEvents:input_type -> geovision.v1.GetEventsRequest
-	4, // 5: geovision.v1.GeoService.GetEventRelatedEntities:input_type -> geovision.v1.GetEventRelatedEntitiesRequest
-	1, // 6: geovision.v1.GeoService.GetEvents:output_type -> geovision.v1.GetEventsResponse
-	5, // 7: geovision.v1.GeoService.GetEventRelatedEntities:output_type -> geovision.v1.GetEventRelatedEntitiesResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	16, // 0: geovision.v1.GetEventsRequest.bbox:type_name -> geovision.v1.BoundingBox
+	53, // 1: geovision.v1.GetEventsResponse.relations:type_name -> model.v1.Relation
+	54, // 2: geovision.v1.GetEventsResponse.events:type_name -> model.v1.Event
+	16, // 3: geovision.v1.ExportEventsRequest.bbox:type_name -> geovision.v1.BoundingBox
+	0,  // 4: geovision.v1.ExportEventsRequest.format:type_name -> geovision.v1.ExportFormat
+	16, // 5: geovision.v1.ExportStixRequest.bbox:type_name -> geovision.v1.BoundingBox
+	16, // 6: geovision.v1.ExportGraphRequest.bbox:type_name -> geovision.v1.BoundingBox
+	1,  // 7: geovision.v1.ExportGraphRequest.format:type_name -> geovision.v1.GraphFormat
+	54, // 8: geovision.v1.GetEventResponse.event:type_name -> model.v1.Event
+	55, // 9: geovision.v1.GetEventRelatedEntitiesResponse.entities:type_name -> model.v1.RelatedEntity
+	54, // 10: geovision.v1.GetEntityFootprintResponse.events:type_name -> model.v1.Event
+	56, // 11: geovision.v1.GetEntityFootprintResponse.track:type_name -> google.protobuf.Struct
+	16, // 12: geovision.v1.GetEntityFootprintResponse.bbox:type_name -> geovision.v1.BoundingBox
+	54, // 13: geovision.v1.CoLocation.target_event:type_name -> model.v1.Event
+	54, // 14: geovision.v1.CoLocation.event:type_name -> model.v1.Event
+	55, // 15: geovision.v1.CoLocatedEntity.entity:type_name -> model.v1.RelatedEntity
+	20, // 16: geovision.v1.CoLocatedEntity.co_locations:type_name -> geovision.v1.CoLocation
+	21, // 17: geovision.v1.FindCoLocatedEntitiesResponse.entities:type_name -> geovision.v1.CoLocatedEntity
+	2,  // 18: geovision.v1.GetAnomaliesRequest.region_grouping:type_name -> geovision.v1.RegionGrouping
+	3,  // 19: geovision.v1.Anomaly.dimension:type_name -> geovision.v1.AnomalyDimension
+	4,  // 20: geovision.v1.Anomaly.direction:type_name -> geovision.v1.AnomalyDirection
+	24, // 21: geovision.v1.GetAnomaliesResponse.anomalies:type_name -> geovision.v1.Anomaly
+	16, // 22: geovision.v1.Place.bbox:type_name -> geovision.v1.BoundingBox
+	26, // 23: geovision.v1.GeocodeResponse.places:type_name -> geovision.v1.Place
+	5,  // 24: geovision.v1.ImportEventsRequest.format:type_name -> geovision.v1.ImportFormat
+	32, // 25: geovision.v1.ImportEventsProgress.errors:type_name -> geovision.v1.ImportRowError
+	56, // 26: geovision.v1.ImportGeoJSONRequest.feature_collection:type_name -> google.protobuf.Struct
+	34, // 27: geovision.v1.ImportGeoJSONRequest.mapping:type_name -> geovision.v1.GeoJSONPropertyMapping
+	36, // 28: geovision.v1.ImportGeoJSONResponse.errors:type_name -> geovision.v1.FeatureError
+	57, // 29: geovision.v1.ImportGpxRequest.sensitivity:type_name -> model.v1.Sensitivity
+	16, // 30: geovision.v1.GetKeyEntitiesRequest.bbox:type_name -> geovision.v1.BoundingBox
+	6,  // 31: geovision.v1.GetKeyEntitiesRequest.rank_by:type_name -> geovision.v1.CentralityMeasure
+	41, // 32: geovision.v1.GetKeyEntitiesResponse.entities:type_name -> geovision.v1.KeyEntity
+	16, // 33: geovision.v1.DetectCommunitiesRequest.bbox:type_name -> geovision.v1.BoundingBox
+	44, // 34: geovision.v1.Community.representatives:type_name -> geovision.v1.CommunityNode
+	16, // 35: geovision.v1.Community.extent:type_name -> geovision.v1.BoundingBox
+	45, // 36: geovision.v1.DetectCommunitiesResponse.communities:type_name -> geovision.v1.Community
+	44, // 37: geovision.v1.DetectCommunitiesResponse.nodes:type_name -> geovision.v1.CommunityNode
+	48, // 38: geovision.v1.GetQuotaResponse.quotas:type_name -> geovision.v1.Quota
+	56, // 39: geovision.v1.AuditEntry.filters:type_name -> google.protobuf.Struct
+	57, // 40: geovision.v1.AuditEntry.sensitivity:type_name -> model.v1.Sensitivity
+	57, // 41: geovision.v1.QueryAuditLogRequest.min_sensitivity:type_name -> model.v1.Sensitivity
+	50, // 42: geovision.v1.QueryAuditLogResponse.entries:type_name -> geovision.v1.AuditEntry
+	7,  // 43: geovision.v1.GeoService.GetEvents:input_type -> geovision.v1.GetEventsRequest
+	14, // 44: geovision.v1.GeoService.GetEventRelatedEntities:input_type -> geovision.v1.GetEventRelatedEntitiesRequest
+	9,  // 45: geovision.v1.GeoService.ExportEvents:input_type -> geovision.v1.ExportEventsRequest
+	10, // 46: geovision.v1.GeoService.ExportStix:input_type -> geovision.v1.ExportStixRequest
+	11, // 47: geovision.v1.GeoService.ExportGraph:input_type -> geovision.v1.ExportGraphRequest
+	17, // 48: geovision.v1.GeoService.GetEntityFootprint:input_type -> geovision.v1.GetEntityFootprintRequest
+	19, // 49: geovision.v1.GeoService.FindCoLocatedEntities:input_type -> geovision.v1.FindCoLocatedEntitiesRequest
+	40, // 50: geovision.v1.GeoService.GetKeyEntities:input_type -> geovision.v1.GetKeyEntitiesRequest
+	43, // 51: geovision.v1.GeoService.DetectCommunities:input_type -> geovision.v1.DetectCommunitiesRequest
+	23, // 52: geovision.v1.GeoService.GetAnomalies:input_type -> geovision.v1.GetAnomaliesRequest
+	27, // 53: geovision.v1.GeoService.Geocode:input_type -> geovision.v1.GeocodeRequest
+	29, // 54: geovision.v1.GeoService.BackfillEventLocations:input_type -> geovision.v1.BackfillEventLocationsRequest
+	31, // 55: geovision.v1.GeoService.ImportEvents:input_type -> geovision.v1.ImportEventsRequest
+	35, // 56: geovision.v1.GeoService.ImportGeoJSON:input_type -> geovision.v1.ImportGeoJSONRequest
+	38, // 57: geovision.v1.GeoService.ImportGpx:input_type -> geovision.v1.ImportGpxRequest
+	47, // 58: geovision.v1.GeoService.GetQuota:input_type -> geovision.v1.GetQuotaRequest
+	51, // 59: geovision.v1.GeoService.QueryAuditLog:input_type -> geovision.v1.QueryAuditLogRequest
+	8,  // 60: geovision.v1.GeoService.GetEvents:output_type -> geovision.v1.GetEventsResponse
+	15, // 61: geovision.v1.GeoService.GetEventRelatedEntities:output_type -> geovision.v1.GetEventRelatedEntitiesResponse
+	58, // 62: geovision.v1.GeoService.ExportEvents:output_type -> google.api.HttpBody
+	58, // 63: geovision.v1.GeoService.ExportStix:output_type -> google.api.HttpBody
+	58, // 64: geovision.v1.GeoService.ExportGraph:output_type -> google.api.HttpBody
+	18, // 65: geovision.v1.GeoService.GetEntityFootprint:output_type -> geovision.v1.GetEntityFootprintResponse
+	22, // 66: geovision.v1.GeoService.FindCoLocatedEntities:output_type -> geovision.v1.FindCoLocatedEntitiesResponse
+	42, // 67: geovision.v1.GeoService.GetKeyEntities:output_type -> geovision.v1.GetKeyEntitiesResponse
+	46, // 68: geovision.v1.GeoService.DetectCommunities:output_type -> geovision.v1.DetectCommunitiesResponse
+	25, // 69: geovision.v1.GeoService.GetAnomalies:output_type -> geovision.v1.GetAnomaliesResponse
+	28, // 70: geovision.v1.GeoService.Geocode:output_type -> geovision.v1.GeocodeResponse
+	30, // 71: geovision.v1.GeoService.BackfillEventLocations:output_type -> geovision.v1.BackfillEventLocationsResponse
+	33, // 72: geovision.v1.GeoService.ImportEvents:output_type -> geovision.v1.ImportEventsProgress
+	37, // 73: geovision.v1.GeoService.ImportGeoJSON:output_type -> geovision.v1.ImportGeoJSONResponse
+	39, // 74: geovision.v1.GeoService.ImportGpx:output_type -> geovision.v1.ImportGpxResponse
+	49, // 75: geovision.v1.GeoService.GetQuota:output_type -> geovision.v1.GetQuotaResponse
+	52, // 76: geovision.v1.GeoService.QueryAuditLog:output_type -> geovision.v1.QueryAuditLogResponse
+	60, // [60:77] is the sub-list for method output_type
+	43, // [43:60] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_geovision_v1_event_service_proto_init() }
@@ -385,13 +4011,14 @@ func file_geovision_v1_event_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_geovision_v1_event_service_proto_rawDesc), len(file_geovision_v1_event_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      7,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_geovision_v1_event_service_proto_goTypes,
 		DependencyIndexes: file_geovision_v1_event_service_proto_depIdxs,
+		EnumInfos:         file_geovision_v1_event_service_proto_enumTypes,
 		MessageInfos:      file_geovision_v1_event_service_proto_msgTypes,
 	}.Build()
 	File_geovision_v1_event_service_proto = out.File
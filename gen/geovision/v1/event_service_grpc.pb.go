@@ -8,6 +8,7 @@ package geovision
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -21,6 +22,21 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	GeoService_GetEvents_FullMethodName               = "/geovision.v1.GeoService/GetEvents"
 	GeoService_GetEventRelatedEntities_FullMethodName = "/geovision.v1.GeoService/GetEventRelatedEntities"
+	GeoService_ExportEvents_FullMethodName            = "/geovision.v1.GeoService/ExportEvents"
+	GeoService_ExportStix_FullMethodName              = "/geovision.v1.GeoService/ExportStix"
+	GeoService_ExportGraph_FullMethodName             = "/geovision.v1.GeoService/ExportGraph"
+	GeoService_GetEntityFootprint_FullMethodName      = "/geovision.v1.GeoService/GetEntityFootprint"
+	GeoService_FindCoLocatedEntities_FullMethodName   = "/geovision.v1.GeoService/FindCoLocatedEntities"
+	GeoService_GetKeyEntities_FullMethodName          = "/geovision.v1.GeoService/GetKeyEntities"
+	GeoService_DetectCommunities_FullMethodName       = "/geovision.v1.GeoService/DetectCommunities"
+	GeoService_GetAnomalies_FullMethodName            = "/geovision.v1.GeoService/GetAnomalies"
+	GeoService_Geocode_FullMethodName                 = "/geovision.v1.GeoService/Geocode"
+	GeoService_BackfillEventLocations_FullMethodName  = "/geovision.v1.GeoService/BackfillEventLocations"
+	GeoService_ImportEvents_FullMethodName            = "/geovision.v1.GeoService/ImportEvents"
+	GeoService_ImportGeoJSON_FullMethodName           = "/geovision.v1.GeoService/ImportGeoJSON"
+	GeoService_ImportGpx_FullMethodName               = "/geovision.v1.GeoService/ImportGpx"
+	GeoService_GetQuota_FullMethodName                = "/geovision.v1.GeoService/GetQuota"
+	GeoService_QueryAuditLog_FullMethodName           = "/geovision.v1.GeoService/QueryAuditLog"
 )
 
 // GeoServiceClient is the client API for GeoService service.
@@ -29,6 +45,43 @@ const (
 type GeoServiceClient interface {
 	GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error)
 	GetEventRelatedEntities(ctx context.Context, in *GetEventRelatedEntitiesRequest, opts ...grpc.CallOption) (*GetEventRelatedEntitiesResponse, error)
+	// Streams the matching events as a file; served over HTTP at
+	// /v1/events/export.csv and /v1/events/export.parquet.
+	ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error)
+	// Returns the matching events, their related entities and relations as a
+	// STIX 2.1 bundle.
+	ExportStix(ctx context.Context, in *ExportStixRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// Returns the subgraph around the matching events as a GraphML or GEXF
+	// file; served over HTTP at /v1/events/export.graphml and
+	// /v1/events/export.gexf.
+	ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	GetEntityFootprint(ctx context.Context, in *GetEntityFootprintRequest, opts ...grpc.CallOption) (*GetEntityFootprintResponse, error)
+	FindCoLocatedEntities(ctx context.Context, in *FindCoLocatedEntitiesRequest, opts ...grpc.CallOption) (*FindCoLocatedEntitiesResponse, error)
+	// Ranks the entities related to the matching events by how central they
+	// are to the resulting graph.
+	GetKeyEntities(ctx context.Context, in *GetKeyEntitiesRequest, opts ...grpc.CallOption) (*GetKeyEntitiesResponse, error)
+	// Splits the graph of the matching events and their related entities into
+	// communities.
+	DetectCommunities(ctx context.Context, in *DetectCommunitiesRequest, opts ...grpc.CallOption) (*DetectCommunitiesResponse, error)
+	GetAnomalies(ctx context.Context, in *GetAnomaliesRequest, opts ...grpc.CallOption) (*GetAnomaliesResponse, error)
+	Geocode(ctx context.Context, in *GeocodeRequest, opts ...grpc.CallOption) (*GeocodeResponse, error)
+	// Fills in the missing admin fields of stored event locations, one page of
+	// candidates per call. Call again with the returned next_page_token until
+	// it comes back empty.
+	BackfillEventLocations(ctx context.Context, in *BackfillEventLocationsRequest, opts ...grpc.CallOption) (*BackfillEventLocationsResponse, error)
+	// Imports events from an ACLED or GDELT export, streaming progress after
+	// every batch.
+	ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ImportEventsProgress], error)
+	// Imports the Point features of a GeoJSON FeatureCollection as events.
+	ImportGeoJSON(ctx context.Context, in *ImportGeoJSONRequest, opts ...grpc.CallOption) (*ImportGeoJSONResponse, error)
+	// Imports the tracks and waypoints of a GPX file. Track points are
+	// simplified and stored as events linked in order by relations.
+	ImportGpx(ctx context.Context, in *ImportGpxRequest, opts ...grpc.CallOption) (*ImportGpxResponse, error)
+	// Returns the rate limits of the caller and how much of each is left.
+	GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error)
+	// Returns who called which RPC and what they got, newest first. Only
+	// admins may read the audit log.
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
 }
 
 type geoServiceClient struct {
@@ -59,12 +112,217 @@ func (c *geoServiceClient) GetEventRelatedEntities(ctx context.Context, in *GetE
 	return out, nil
 }
 
+func (c *geoServiceClient) ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GeoService_ServiceDesc.Streams[0], GeoService_ExportEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportEventsRequest, httpbody.HttpBody]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeoService_ExportEventsClient = grpc.ServerStreamingClient[httpbody.HttpBody]
+
+func (c *geoServiceClient) ExportStix(ctx context.Context, in *ExportStixRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, GeoService_ExportStix_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, GeoService_ExportGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetEntityFootprint(ctx context.Context, in *GetEntityFootprintRequest, opts ...grpc.CallOption) (*GetEntityFootprintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEntityFootprintResponse)
+	err := c.cc.Invoke(ctx, GeoService_GetEntityFootprint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) FindCoLocatedEntities(ctx context.Context, in *FindCoLocatedEntitiesRequest, opts ...grpc.CallOption) (*FindCoLocatedEntitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindCoLocatedEntitiesResponse)
+	err := c.cc.Invoke(ctx, GeoService_FindCoLocatedEntities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetKeyEntities(ctx context.Context, in *GetKeyEntitiesRequest, opts ...grpc.CallOption) (*GetKeyEntitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyEntitiesResponse)
+	err := c.cc.Invoke(ctx, GeoService_GetKeyEntities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) DetectCommunities(ctx context.Context, in *DetectCommunitiesRequest, opts ...grpc.CallOption) (*DetectCommunitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectCommunitiesResponse)
+	err := c.cc.Invoke(ctx, GeoService_DetectCommunities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetAnomalies(ctx context.Context, in *GetAnomaliesRequest, opts ...grpc.CallOption) (*GetAnomaliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAnomaliesResponse)
+	err := c.cc.Invoke(ctx, GeoService_GetAnomalies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) Geocode(ctx context.Context, in *GeocodeRequest, opts ...grpc.CallOption) (*GeocodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeocodeResponse)
+	err := c.cc.Invoke(ctx, GeoService_Geocode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) BackfillEventLocations(ctx context.Context, in *BackfillEventLocationsRequest, opts ...grpc.CallOption) (*BackfillEventLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackfillEventLocationsResponse)
+	err := c.cc.Invoke(ctx, GeoService_BackfillEventLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ImportEventsProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GeoService_ServiceDesc.Streams[1], GeoService_ImportEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportEventsRequest, ImportEventsProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeoService_ImportEventsClient = grpc.ServerStreamingClient[ImportEventsProgress]
+
+func (c *geoServiceClient) ImportGeoJSON(ctx context.Context, in *ImportGeoJSONRequest, opts ...grpc.CallOption) (*ImportGeoJSONResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportGeoJSONResponse)
+	err := c.cc.Invoke(ctx, GeoService_ImportGeoJSON_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) ImportGpx(ctx context.Context, in *ImportGpxRequest, opts ...grpc.CallOption) (*ImportGpxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportGpxResponse)
+	err := c.cc.Invoke(ctx, GeoService_ImportGpx_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaResponse)
+	err := c.cc.Invoke(ctx, GeoService_GetQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, GeoService_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GeoServiceServer is the server API for GeoService service.
 // All implementations must embed UnimplementedGeoServiceServer
 // for forward compatibility.
 type GeoServiceServer interface {
 	GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error)
 	GetEventRelatedEntities(context.Context, *GetEventRelatedEntitiesRequest) (*GetEventRelatedEntitiesResponse, error)
+	// Streams the matching events as a file; served over HTTP at
+	// /v1/events/export.csv and /v1/events/export.parquet.
+	ExportEvents(*ExportEventsRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error
+	// Returns the matching events, their related entities and relations as a
+	// STIX 2.1 bundle.
+	ExportStix(context.Context, *ExportStixRequest) (*httpbody.HttpBody, error)
+	// Returns the subgraph around the matching events as a GraphML or GEXF
+	// file; served over HTTP at /v1/events/export.graphml and
+	// /v1/events/export.gexf.
+	ExportGraph(context.Context, *ExportGraphRequest) (*httpbody.HttpBody, error)
+	GetEntityFootprint(context.Context, *GetEntityFootprintRequest) (*GetEntityFootprintResponse, error)
+	FindCoLocatedEntities(context.Context, *FindCoLocatedEntitiesRequest) (*FindCoLocatedEntitiesResponse, error)
+	// Ranks the entities related to the matching events by how central they
+	// are to the resulting graph.
+	GetKeyEntities(context.Context, *GetKeyEntitiesRequest) (*GetKeyEntitiesResponse, error)
+	// Splits the graph of the matching events and their related entities into
+	// communities.
+	DetectCommunities(context.Context, *DetectCommunitiesRequest) (*DetectCommunitiesResponse, error)
+	GetAnomalies(context.Context, *GetAnomaliesRequest) (*GetAnomaliesResponse, error)
+	Geocode(context.Context, *GeocodeRequest) (*GeocodeResponse, error)
+	// Fills in the missing admin fields of stored event locations, one page of
+	// candidates per call. Call again with the returned next_page_token until
+	// it comes back empty.
+	BackfillEventLocations(context.Context, *BackfillEventLocationsRequest) (*BackfillEventLocationsResponse, error)
+	// Imports events from an ACLED or GDELT export, streaming progress after
+	// every batch.
+	ImportEvents(*ImportEventsRequest, grpc.ServerStreamingServer[ImportEventsProgress]) error
+	// Imports the Point features of a GeoJSON FeatureCollection as events.
+	ImportGeoJSON(context.Context, *ImportGeoJSONRequest) (*ImportGeoJSONResponse, error)
+	// Imports the tracks and waypoints of a GPX file. Track points are
+	// simplified and stored as events linked in order by relations.
+	ImportGpx(context.Context, *ImportGpxRequest) (*ImportGpxResponse, error)
+	// Returns the rate limits of the caller and how much of each is left.
+	GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error)
+	// Returns who called which RPC and what they got, newest first. Only
+	// admins may read the audit log.
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
 	mustEmbedUnimplementedGeoServiceServer()
 }
 
@@ -81,6 +339,51 @@ func (UnimplementedGeoServiceServer) GetEvents(context.Context, *GetEventsReques
 func (UnimplementedGeoServiceServer) GetEventRelatedEntities(context.Context, *GetEventRelatedEntitiesRequest) (*GetEventRelatedEntitiesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEventRelatedEntities not implemented")
 }
+func (UnimplementedGeoServiceServer) ExportEvents(*ExportEventsRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportEvents not implemented")
+}
+func (UnimplementedGeoServiceServer) ExportStix(context.Context, *ExportStixRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportStix not implemented")
+}
+func (UnimplementedGeoServiceServer) ExportGraph(context.Context, *ExportGraphRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportGraph not implemented")
+}
+func (UnimplementedGeoServiceServer) GetEntityFootprint(context.Context, *GetEntityFootprintRequest) (*GetEntityFootprintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntityFootprint not implemented")
+}
+func (UnimplementedGeoServiceServer) FindCoLocatedEntities(context.Context, *FindCoLocatedEntitiesRequest) (*FindCoLocatedEntitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindCoLocatedEntities not implemented")
+}
+func (UnimplementedGeoServiceServer) GetKeyEntities(context.Context, *GetKeyEntitiesRequest) (*GetKeyEntitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyEntities not implemented")
+}
+func (UnimplementedGeoServiceServer) DetectCommunities(context.Context, *DetectCommunitiesRequest) (*DetectCommunitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetectCommunities not implemented")
+}
+func (UnimplementedGeoServiceServer) GetAnomalies(context.Context, *GetAnomaliesRequest) (*GetAnomaliesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnomalies not implemented")
+}
+func (UnimplementedGeoServiceServer) Geocode(context.Context, *GeocodeRequest) (*GeocodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Geocode not implemented")
+}
+func (UnimplementedGeoServiceServer) BackfillEventLocations(context.Context, *BackfillEventLocationsRequest) (*BackfillEventLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackfillEventLocations not implemented")
+}
+func (UnimplementedGeoServiceServer) ImportEvents(*ImportEventsRequest, grpc.ServerStreamingServer[ImportEventsProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportEvents not implemented")
+}
+func (UnimplementedGeoServiceServer) ImportGeoJSON(context.Context, *ImportGeoJSONRequest) (*ImportGeoJSONResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportGeoJSON not implemented")
+}
+func (UnimplementedGeoServiceServer) ImportGpx(context.Context, *ImportGpxRequest) (*ImportGpxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportGpx not implemented")
+}
+func (UnimplementedGeoServiceServer) GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuota not implemented")
+}
+func (UnimplementedGeoServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
 func (UnimplementedGeoServiceServer) mustEmbedUnimplementedGeoServiceServer() {}
 func (UnimplementedGeoServiceServer) testEmbeddedByValue()                    {}
 
@@ -138,6 +441,262 @@ func _GeoService_GetEventRelatedEntities_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GeoService_ExportEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoServiceServer).ExportEvents(m, &grpc.GenericServerStream[ExportEventsRequest, httpbody.HttpBody]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeoService_ExportEventsServer = grpc.ServerStreamingServer[httpbody.HttpBody]
+
+func _GeoService_ExportStix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportStixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).ExportStix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_ExportStix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).ExportStix(ctx, req.(*ExportStixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_ExportGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).ExportGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_ExportGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).ExportGraph(ctx, req.(*ExportGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetEntityFootprint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntityFootprintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetEntityFootprint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_GetEntityFootprint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetEntityFootprint(ctx, req.(*GetEntityFootprintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_FindCoLocatedEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindCoLocatedEntitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).FindCoLocatedEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_FindCoLocatedEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).FindCoLocatedEntities(ctx, req.(*FindCoLocatedEntitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetKeyEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyEntitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetKeyEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_GetKeyEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetKeyEntities(ctx, req.(*GetKeyEntitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_DetectCommunities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectCommunitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).DetectCommunities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_DetectCommunities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).DetectCommunities(ctx, req.(*DetectCommunitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetAnomalies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnomaliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetAnomalies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_GetAnomalies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetAnomalies(ctx, req.(*GetAnomaliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_Geocode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeocodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).Geocode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_Geocode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).Geocode(ctx, req.(*GeocodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_BackfillEventLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillEventLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).BackfillEventLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_BackfillEventLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).BackfillEventLocations(ctx, req.(*BackfillEventLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_ImportEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImportEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoServiceServer).ImportEvents(m, &grpc.GenericServerStream[ImportEventsRequest, ImportEventsProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeoService_ImportEventsServer = grpc.ServerStreamingServer[ImportEventsProgress]
+
+func _GeoService_ImportGeoJSON_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportGeoJSONRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).ImportGeoJSON(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_ImportGeoJSON_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).ImportGeoJSON(ctx, req.(*ImportGeoJSONRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_ImportGpx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportGpxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).ImportGpx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_ImportGpx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).ImportGpx(ctx, req.(*ImportGpxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_GetQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetQuota(ctx, req.(*GetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_QueryAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GeoService_ServiceDesc is the grpc.ServiceDesc for GeoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +712,70 @@ var GeoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEventRelatedEntities",
 			Handler:    _GeoService_GetEventRelatedEntities_Handler,
 		},
+		{
+			MethodName: "ExportStix",
+			Handler:    _GeoService_ExportStix_Handler,
+		},
+		{
+			MethodName: "ExportGraph",
+			Handler:    _GeoService_ExportGraph_Handler,
+		},
+		{
+			MethodName: "GetEntityFootprint",
+			Handler:    _GeoService_GetEntityFootprint_Handler,
+		},
+		{
+			MethodName: "FindCoLocatedEntities",
+			Handler:    _GeoService_FindCoLocatedEntities_Handler,
+		},
+		{
+			MethodName: "GetKeyEntities",
+			Handler:    _GeoService_GetKeyEntities_Handler,
+		},
+		{
+			MethodName: "DetectCommunities",
+			Handler:    _GeoService_DetectCommunities_Handler,
+		},
+		{
+			MethodName: "GetAnomalies",
+			Handler:    _GeoService_GetAnomalies_Handler,
+		},
+		{
+			MethodName: "Geocode",
+			Handler:    _GeoService_Geocode_Handler,
+		},
+		{
+			MethodName: "BackfillEventLocations",
+			Handler:    _GeoService_BackfillEventLocations_Handler,
+		},
+		{
+			MethodName: "ImportGeoJSON",
+			Handler:    _GeoService_ImportGeoJSON_Handler,
+		},
+		{
+			MethodName: "ImportGpx",
+			Handler:    _GeoService_ImportGpx_Handler,
+		},
+		{
+			MethodName: "GetQuota",
+			Handler:    _GeoService_GetQuota_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _GeoService_QueryAuditLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportEvents",
+			Handler:       _GeoService_ExportEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportEvents",
+			Handler:       _GeoService_ImportEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "geovision/v1/event_service.proto",
 }
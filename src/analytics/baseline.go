@@ -0,0 +1,117 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+)
+
+// Deviation describes how an observed count compares to its baseline.
+type Deviation struct {
+	Key      string
+	Observed int64
+	Expected float64
+	StdDev   float64
+	ZScore   float64
+}
+
+// Baseline accumulates counts per key over a number of historical windows and
+// the window under observation.
+type Baseline struct {
+	windows  int
+	history  map[string][]int64
+	observed map[string]int64
+}
+
+// NewBaseline creates a baseline over the given number of historical windows.
+func NewBaseline(windows int) *Baseline {
+	return &Baseline{
+		windows:  windows,
+		history:  make(map[string][]int64),
+		observed: make(map[string]int64),
+	}
+}
+
+// AddHistorical counts n occurrences of key in the given historical window.
+func (b *Baseline) AddHistorical(key string, window int, n int64) {
+	if window < 0 || window >= b.windows {
+		return
+	}
+	counts, ok := b.history[key]
+	if !ok {
+		counts = make([]int64, b.windows)
+		b.history[key] = counts
+	}
+	counts[window] += n
+}
+
+// AddObserved counts n occurrences of key in the observed window.
+func (b *Baseline) AddObserved(key string, n int64) {
+	b.observed[key] += n
+}
+
+// Deviations returns every key whose observed count deviates from the
+// historical mean by at least threshold standard deviations, ordered by the
+// magnitude of the deviation. The standard deviation is floored at the
+// square root of the mean (and at 1) so sparse keys are judged against
+// Poisson noise instead of a near-zero spread.
+func (b *Baseline) Deviations(threshold float64) []Deviation {
+	keys := make(map[string]struct{}, len(b.history)+len(b.observed))
+	for key := range b.history {
+		keys[key] = struct{}{}
+	}
+	for key := range b.observed {
+		keys[key] = struct{}{}
+	}
+
+	var deviations []Deviation
+	for key := range keys {
+		mean, stddev := meanStdDev(b.history[key], b.windows)
+		spread := math.Max(stddev, math.Max(math.Sqrt(mean), 1))
+		observed := b.observed[key]
+		z := (float64(observed) - mean) / spread
+
+		if math.Abs(z) < threshold {
+			continue
+		}
+		deviations = append(deviations, Deviation{
+			Key:      key,
+			Observed: observed,
+			Expected: mean,
+			StdDev:   stddev,
+			ZScore:   z,
+		})
+	}
+
+	sort.Slice(deviations, func(i, j int) bool {
+		zi, zj := math.Abs(deviations[i].ZScore), math.Abs(deviations[j].ZScore)
+		if zi != zj {
+			return zi > zj
+		}
+		return deviations[i].Key < deviations[j].Key
+	})
+	return deviations
+}
+
+// meanStdDev computes the population mean and standard deviation of counts
+// over n windows; missing counts are treated as zero.
+func meanStdDev(counts []int64, n int) (float64, float64) {
+	if n == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, c := range counts {
+		sum += float64(c)
+	}
+	mean := sum / float64(n)
+
+	var variance float64
+	for i := 0; i < n; i++ {
+		var c float64
+		if i < len(counts) {
+			c = float64(counts[i])
+		}
+		variance += (c - mean) * (c - mean)
+	}
+	return mean, math.Sqrt(variance / float64(n))
+}
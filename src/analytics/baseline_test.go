@@ -0,0 +1,55 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBaselineDeviations(t *testing.T) {
+	baseline := NewBaseline(4)
+
+	// "UA" is steady at 10 per window and spikes to 40
+	for window := 0; window < 4; window++ {
+		for i := 0; i < 10; i++ {
+			baseline.AddHistorical("UA", window, 1)
+		}
+	}
+	for i := 0; i < 40; i++ {
+		baseline.AddObserved("UA", 1)
+	}
+
+	// "PL" is steady at 9 per window and disappears
+	for window := 0; window < 4; window++ {
+		for i := 0; i < 9; i++ {
+			baseline.AddHistorical("PL", window, 1)
+		}
+	}
+
+	// "DE" stays within its usual range
+	for window := 0; window < 4; window++ {
+		baseline.AddHistorical("DE", window, 1)
+	}
+	baseline.AddObserved("DE", 1)
+
+	// Out of range windows are ignored
+	baseline.AddHistorical("DE", 4, 1)
+	baseline.AddHistorical("DE", -1, 1)
+
+	deviations := baseline.Deviations(3)
+	if len(deviations) != 2 {
+		t.Fatalf("Expected 2 deviations, got %d: %v", len(deviations), deviations)
+	}
+
+	spike := deviations[0]
+	if spike.Key != "UA" || spike.Observed != 40 || spike.Expected != 10 {
+		t.Errorf("Unexpected spike: %+v", spike)
+	}
+	if math.Abs(spike.ZScore-30/math.Sqrt(10)) > 1e-9 {
+		t.Errorf("Expected z-score floored at Poisson noise, got %v", spike.ZScore)
+	}
+
+	drop := deviations[1]
+	if drop.Key != "PL" || drop.Observed != 0 || drop.ZScore != -3 {
+		t.Errorf("Unexpected drop: %+v", drop)
+	}
+}
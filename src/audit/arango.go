@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+)
+
+// Filter selects entries of the audit log. Zero fields match everything.
+type Filter struct {
+	Subject string
+	RPC     string
+	// StartTime and EndTime bound the time of entries, in Unix seconds
+	StartTime      int64
+	EndTime        int64
+	MinSensitivity int32
+	Limit          int
+}
+
+// Store keeps entries and answers queries over them.
+type Store interface {
+	Sink
+	// Query returns up to filter.Limit matching entries, newest first.
+	Query(ctx context.Context, filter Filter) ([]Entry, error)
+}
+
+// ArangoStore keeps the audit log in a collection of its own, outside of the
+// OSINT graph.
+type ArangoStore struct {
+	db         driver.Database
+	collection driver.Collection
+}
+
+// NewArangoStore creates the collection and its indexes when missing.
+func NewArangoStore(ctx context.Context, db driver.Database, name string) (*ArangoStore, error) {
+	exists, err := db.CollectionExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var collection driver.Collection
+	if exists {
+		collection, err = db.Collection(ctx, name)
+	} else {
+		collection, err = db.CreateCollection(ctx, name, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fields := range [][]string{{"time"}, {"subject", "time"}} {
+		if _, _, err := collection.EnsurePersistentIndex(ctx, fields, &driver.EnsurePersistentIndexOptions{
+			InBackground: true,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return &ArangoStore{db: db, collection: collection}, nil
+}
+
+func (s *ArangoStore) Write(ctx context.Context, entries []Entry) error {
+	_, errs, err := s.collection.CreateDocuments(ctx, entries)
+	if err != nil {
+		return err
+	}
+	return errs.FirstNonNil()
+}
+
+func (s *ArangoStore) Query(ctx context.Context, filter Filter) ([]Entry, error) {
+	query := `
+		FOR entry IN @@collection
+			FILTER @subject == "" || entry.subject == @subject
+			FILTER @rpc == "" || entry.rpc == @rpc
+			FILTER @start_time == 0 || entry.time >= @start_time
+			FILTER @end_time == 0 || entry.time <= @end_time
+			FILTER entry.sensitivity >= @min_sensitivity
+			SORT entry.time DESC
+			LIMIT @limit
+			RETURN UNSET(entry, "_id", "_key", "_rev")
+	`
+
+	cursor, err := s.db.Query(ctx, query, map[string]interface{}{
+		"@collection":     s.collection.Name(),
+		"subject":         filter.Subject,
+		"rpc":             filter.RPC,
+		"start_time":      filter.StartTime,
+		"end_time":        filter.EndTime,
+		"min_sensitivity": filter.MinSensitivity,
+		"limit":           filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var entries []Entry
+	for {
+		var entry Entry
+		_, err := cursor.ReadDocument(ctx, &entry)
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
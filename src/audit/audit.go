@@ -0,0 +1,328 @@
+package audit
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/geovision/src/metrics"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Sizes of the queue of entries waiting to be written and of the batches
+// written at once.
+const (
+	queueSize = 1024
+	batchSize = 100
+)
+
+// writeTimeout bounds the writing of one batch to one sink.
+const writeTimeout = 10 * time.Second
+
+// sensitivityEnum is the enum whose highest value returned is recorded.
+const sensitivityEnum protoreflect.FullName = "model.v1.Sensitivity"
+
+// Entry records one call. Sensitivity is stored as its enum number, like in
+// the documents it was read from.
+type Entry struct {
+	// Time is in Unix seconds
+	Time        int64                  `json:"time"`
+	Subject     string                 `json:"subject,omitempty"`
+	Username    string                 `json:"username,omitempty"`
+	Roles       []string               `json:"roles,omitempty"`
+	RPC         string                 `json:"rpc"`
+	Filters     map[string]interface{} `json:"filters,omitempty"`
+	Results     int                    `json:"results"`
+	Sensitivity int32                  `json:"sensitivity"`
+	Code        string                 `json:"code"`
+	DurationMs  float64                `json:"duration_ms"`
+	// Authenticated tells whether the identity was verified
+	Authenticated bool `json:"authenticated"`
+}
+
+// Sink writes entries somewhere they are kept.
+type Sink interface {
+	Write(ctx context.Context, entries []Entry) error
+}
+
+// Recorder records every call to one gRPC service and writes the entries to
+// its sinks in the background, so that calls do not wait on them.
+type Recorder struct {
+	service  string
+	clientID string
+	sinks    []Sink
+	now      func() time.Time
+
+	mu      sync.RWMutex
+	closed  bool
+	entries chan Entry
+	done    chan struct{}
+}
+
+// NewRecorder records the calls to service in the sinks, with the roles of
+// callers read from the realm and from the given Keycloak client. Run must
+// be started for entries to be written.
+func NewRecorder(service, clientID string, sinks ...Sink) *Recorder {
+	return &Recorder{
+		service:  service,
+		clientID: clientID,
+		sinks:    sinks,
+		now:      time.Now,
+		entries:  make(chan Entry, queueSize),
+		done:     make(chan struct{}),
+	}
+}
+
+// Run writes queued entries in batches until the recorder is closed and the
+// queue drained.
+func (r *Recorder) Run() {
+	defer close(r.done)
+	for entry := range r.entries {
+		batch := []Entry{entry}
+	fill:
+		for len(batch) < batchSize {
+			select {
+			case entry, ok := <-r.entries:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, entry)
+			default:
+				break fill
+			}
+		}
+		r.write(batch)
+	}
+}
+
+func (r *Recorder) write(batch []Entry) {
+	for _, sink := range r.sinks {
+		ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+		err := sink.Write(ctx, batch)
+		cancel()
+		if err != nil {
+			// Keep the entries in the service log rather than lose them
+			for _, entry := range batch {
+				logrus.WithFields(logrus.Fields{
+					"error": err,
+					"entry": entry,
+				}).Error("failed to write audit entry")
+			}
+		}
+	}
+}
+
+// Close stops accepting entries and waits until the queued ones are written.
+func (r *Recorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.entries)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Record queues an entry, for calls and feeds that the interceptors do not
+// see. Entries recorded after Close are only logged. Calls never wait on the
+// sinks: when they fall so far behind that the queue is full, the entry is
+// kept in the service log instead and counted as dropped.
+func (r *Recorder) Record(entry Entry) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		logrus.WithFields(logrus.Fields{
+			"entry": entry,
+		}).Warn("audit log closed, entry not written")
+		return
+	}
+	select {
+	case r.entries <- entry:
+	default:
+		metrics.ObserveAuditDropped()
+		logrus.WithFields(logrus.Fields{
+			"entry": entry,
+		}).Error("audit queue full, entry not written")
+	}
+}
+
+// UnaryServerInterceptor records the call once it is answered.
+func (r *Recorder) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method, ok := strings.CutPrefix(info.FullMethod, "/"+r.service+"/")
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	start := r.now()
+	report := &Report{}
+	resp, err := handler(context.WithValue(ctx, reportKey{}, report), req)
+	if !report.set {
+		if message, ok := resp.(proto.Message); ok && err == nil {
+			report.Results, report.Sensitivity = inspect(message.ProtoReflect())
+		}
+	}
+	r.Record(r.entry(ctx, method, req, report, start, err))
+	return resp, err
+}
+
+// StreamServerInterceptor records streaming calls once they end. Their
+// results are only known when the handler reports them.
+func (r *Recorder) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	method, ok := strings.CutPrefix(info.FullMethod, "/"+r.service+"/")
+	if !ok {
+		return handler(srv, stream)
+	}
+
+	start := r.now()
+	report := &Report{}
+	recorded := &recordedStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), reportKey{}, report)}
+	err := handler(srv, recorded)
+	r.Record(r.entry(stream.Context(), method, recorded.request, report, start, err))
+	return err
+}
+
+func (r *Recorder) entry(ctx context.Context, method string, req interface{}, report *Report, start time.Time, err error) Entry {
+	entry := Entry{
+		Time:        start.Unix(),
+		RPC:         method,
+		Results:     report.Results,
+		Sensitivity: report.Sensitivity,
+		Code:        status.Code(err).String(),
+		DurationMs:  float64(r.now().Sub(start).Microseconds()) / 1000,
+	}
+	// Only verified tokens are trusted; claims of any other call are left out
+	if identity, ok := auth.FromContext(ctx, r.clientID); ok {
+		entry.Subject = identity.Subject
+		entry.Username = identity.Username
+		entry.Roles = identity.Roles
+		entry.Authenticated = true
+	}
+	if message, ok := req.(proto.Message); ok {
+		entry.Filters = filters(message.ProtoReflect())
+	}
+	return entry
+}
+
+// recordedStream keeps the request of a server streaming call and passes the
+// report on to the handler.
+type recordedStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	request interface{}
+}
+
+func (s *recordedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recordedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.request == nil {
+		s.request = m
+	}
+	return err
+}
+
+type reportKey struct{}
+
+// Report holds what a call returned. Handlers whose responses do not show it,
+// such as file exports, set it with SetReport.
+type Report struct {
+	Results     int
+	Sensitivity int32
+
+	set bool
+}
+
+// SetReport records the number of items a call returned and the highest
+// sensitivity among them, if the call is audited.
+func SetReport(ctx context.Context, results int, sensitivity int32) {
+	if report, ok := ctx.Value(reportKey{}).(*Report); ok {
+		report.Results, report.Sensitivity, report.set = results, sensitivity, true
+	}
+}
+
+// Inspect counts the items in the repeated fields of a message and finds the
+// highest sensitivity anywhere in it, as is recorded for responses.
+func Inspect(message proto.Message) (int, int32) {
+	return inspect(message.ProtoReflect())
+}
+
+func inspect(m protoreflect.Message) (int, int32) {
+	results := 0
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsList() && fd.Kind() == protoreflect.MessageKind {
+			results += v.List().Len()
+		}
+		return true
+	})
+	return results, highestSensitivity(m)
+}
+
+func highestSensitivity(m protoreflect.Message) int32 {
+	var highest int32
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+		case fd.Kind() == protoreflect.EnumKind && fd.Enum().FullName() == sensitivityEnum && !fd.IsList():
+			highest = max(highest, int32(v.Enum()))
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				highest = max(highest, highestSensitivity(v.List().Get(i).Message()))
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			highest = max(highest, highestSensitivity(v.Message()))
+		}
+		return true
+	})
+	return highest
+}
+
+// filters returns the fields set in a request. File contents and free-form
+// documents, such as GeoJSON, are left out.
+func filters(m protoreflect.Message) map[string]interface{} {
+	out := make(map[string]interface{})
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		switch {
+		case fd.IsMap(), fd.Kind() == protoreflect.BytesKind:
+		case fd.Kind() == protoreflect.MessageKind:
+			if fd.IsList() || fd.Message().ParentFile().Package() == "google.protobuf" {
+				break
+			}
+			out[name] = filters(v.Message())
+		case fd.IsList():
+			values := make([]interface{}, v.List().Len())
+			for i := range values {
+				values[i] = scalar(fd, v.List().Get(i))
+			}
+			out[name] = values
+		default:
+			out[name] = scalar(fd, v)
+		}
+		return true
+	})
+	return out
+}
+
+func scalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	if fd.Kind() == protoreflect.EnumKind {
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return int32(v.Enum())
+	}
+	return v.Interface()
+}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const service = "geovision.v1.GeoService"
+
+type memorySink struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func (s *memorySink) Write(ctx context.Context, entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+func TestInspect(t *testing.T) {
+	results, sensitivity := Inspect(&geovision.GetEventsResponse{
+		Events: []*model.Event{
+			{Id: "events/1", Sensitivity: model.Sensitivity_SENSITIVITY_PRIVILEGED},
+			{Id: "events/2", Sensitivity: model.Sensitivity_SENSITIVITY_CONFIDENTIAL},
+		},
+		Relations: []*model.Relation{{From: "events/1", To: "events/2"}},
+	})
+	if results != 3 {
+		t.Errorf("Expected 3 results, got %d", results)
+	}
+	if sensitivity != int32(model.Sensitivity_SENSITIVITY_CONFIDENTIAL) {
+		t.Errorf("Expected the highest sensitivity to be confidential, got %d", sensitivity)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	collection, _ := structpb.NewStruct(map[string]interface{}{"type": "FeatureCollection"})
+	got := filters((&geovision.ImportGeoJSONRequest{
+		FeatureCollection: collection,
+		DryRun:            true,
+	}).ProtoReflect())
+	if _, ok := got["feature_collection"]; ok {
+		t.Error("Expected the GeoJSON document to be left out")
+	}
+	if got["dry_run"] != true {
+		t.Errorf("Expected dry_run to be recorded, got %v", got["dry_run"])
+	}
+
+	got = filters((&geovision.GetEventsRequest{
+		StartTime: 100,
+		Bbox:      &geovision.BoundingBox{MinLatitude: 1},
+	}).ProtoReflect())
+	if got["start_time"] != int64(100) {
+		t.Errorf("Expected start_time 100, got %v", got["start_time"])
+	}
+	if bbox, ok := got["bbox"].(map[string]interface{}); !ok || bbox["min_latitude"] != float64(1) {
+		t.Errorf("Expected the bounding box to be recorded, got %v", got["bbox"])
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	call := func(r *Recorder, method string, handler grpc.UnaryHandler) {
+		r.UnaryServerInterceptor(context.Background(), &geovision.GetEventsRequest{StartTime: 100},
+			&grpc.UnaryServerInfo{FullMethod: "/" + service + "/" + method}, handler)
+	}
+
+	t.Run("Records Calls", func(t *testing.T) {
+		sink := &memorySink{}
+		r := NewRecorder(service, "geovision", sink)
+		go r.Run()
+
+		call(r, "GetEvents", func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &geovision.GetEventsResponse{Events: []*model.Event{
+				{Id: "events/1", Sensitivity: model.Sensitivity_SENSITIVITY_COMMERCIAL},
+			}}, nil
+		})
+		call(r, "ExportEvents", func(ctx context.Context, req interface{}) (interface{}, error) {
+			SetReport(ctx, 7, int32(model.Sensitivity_SENSITIVITY_PRIVILEGED))
+			return nil, nil
+		})
+		call(r, "GetEvents", func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Errorf(codes.InvalidArgument, "bad range")
+		})
+		if err := r.Close(context.Background()); err != nil {
+			t.Fatalf("Expected the recorder to close, got %v", err)
+		}
+
+		if len(sink.entries) != 3 {
+			t.Fatalf("Expected 3 entries, got %d", len(sink.entries))
+		}
+		first := sink.entries[0]
+		if first.RPC != "GetEvents" || first.Results != 1 || first.Sensitivity != int32(model.Sensitivity_SENSITIVITY_COMMERCIAL) || first.Code != "OK" {
+			t.Errorf("Expected the response to be inspected, got %+v", first)
+		}
+		if first.Filters["start_time"] != int64(100) {
+			t.Errorf("Expected the request filters, got %v", first.Filters)
+		}
+		if reported := sink.entries[1]; reported.Results != 7 || reported.Sensitivity != int32(model.Sensitivity_SENSITIVITY_PRIVILEGED) {
+			t.Errorf("Expected the reported results, got %+v", reported)
+		}
+		if failed := sink.entries[2]; failed.Code != "InvalidArgument" || failed.Results != 0 {
+			t.Errorf("Expected the failed call to be recorded, got %+v", failed)
+		}
+	})
+
+	t.Run("Verified Identity", func(t *testing.T) {
+		sink := &memorySink{}
+		r := NewRecorder(service, "geovision", sink)
+		go r.Run()
+
+		token := "Bearer e30." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub": "user-1"}`)) + ".sig"
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", token))
+		info := &grpc.UnaryServerInfo{FullMethod: "/" + service + "/GetEvents"}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+		r.UnaryServerInterceptor(ctx, nil, info, handler)
+		r.UnaryServerInterceptor(auth.Verified(ctx), nil, info, handler)
+		r.Close(context.Background())
+
+		if len(sink.entries) != 2 {
+			t.Fatalf("Expected 2 entries, got %d", len(sink.entries))
+		}
+		if forged := sink.entries[0]; forged.Subject != "" || forged.Authenticated {
+			t.Errorf("Expected the unverified call to be unauthenticated, got %+v", forged)
+		}
+		if verified := sink.entries[1]; verified.Subject != "user-1" || !verified.Authenticated {
+			t.Errorf("Expected the verified subject, got %+v", verified)
+		}
+	})
+
+	t.Run("Full Queue", func(t *testing.T) {
+		// Without Run nothing drains the queue, as with a stalled sink
+		r := NewRecorder(service, "geovision", &memorySink{})
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i <= queueSize; i++ {
+				r.Record(Entry{RPC: "GetEvents"})
+			}
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected recording not to block on a full queue")
+		}
+	})
+
+	t.Run("Other Services", func(t *testing.T) {
+		sink := &memorySink{}
+		r := NewRecorder(service, "geovision", sink)
+		go r.Run()
+
+		r.UnaryServerInterceptor(context.Background(), nil,
+			&grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"},
+			func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+		r.Close(context.Background())
+
+		if len(sink.entries) != 0 {
+			t.Errorf("Expected no entries for other services, got %d", len(sink.entries))
+		}
+	})
+}
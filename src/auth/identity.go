@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// AdminRole is the role required by administrative RPCs.
+const AdminRole = "admin"
+
+// Identity is the caller of an RPC as described by its access token.
+type Identity struct {
+	Subject  string
+	Username string
+	Roles    []string
+}
+
+// claims is the subset of Keycloak access token claims we rely on.
+type claims struct {
+	Subject           string `json:"sub"`
+	PreferredUsername string `json:"preferred_username"`
+	RealmAccess       struct {
+		Roles []string `json:"roles"`
+	} `json:"realm_access"`
+	ResourceAccess map[string]struct {
+		Roles []string `json:"roles"`
+	} `json:"resource_access"`
+}
+
+// FromContext returns the identity carried by the bearer token of the
+// incoming request, with the realm roles and the roles of the given client.
+// The token is only decoded here, so it is trusted only once the identity
+// interceptors have checked it, as marked by Verified.
+func FromContext(ctx context.Context, clientID string) (*Identity, bool) {
+	if verified, _ := ctx.Value(verifiedKey{}).(bool); !verified {
+		return nil, false
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	for _, value := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if !ok {
+			continue
+		}
+
+		c, ok := decodeClaims(token)
+		if !ok {
+			continue
+		}
+
+		identity := &Identity{
+			Subject:  c.Subject,
+			Username: c.PreferredUsername,
+			Roles:    append([]string{}, c.RealmAccess.Roles...),
+		}
+		if client, ok := c.ResourceAccess[clientID]; ok {
+			identity.Roles = append(identity.Roles, client.Roles...)
+		}
+		return identity, true
+	}
+
+	return nil, false
+}
+
+// HasRole reports whether the identity has the given role.
+func (i *Identity) HasRole(role string) bool {
+	if i == nil {
+		return false
+	}
+	for _, r := range i.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeClaims(token string) (*claims, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, false
+	}
+
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, false
+	}
+	return &c, true
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func token(payload string) string {
+	return "Bearer e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func TestFromContext(t *testing.T) {
+	payload := `{
+		"sub": "user-1",
+		"preferred_username": "analyst",
+		"realm_access": {"roles": ["viewer"]},
+		"resource_access": {"geovision": {"roles": ["admin"]}, "other": {"roles": ["owner"]}}
+	}`
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", token(payload)))
+	if _, ok := FromContext(ctx, "geovision"); ok {
+		t.Error("Expected no identity before the token is verified")
+	}
+
+	identity, ok := FromContext(Verified(ctx), "geovision")
+	if !ok {
+		t.Fatal("Expected identity to be found")
+	}
+	if identity.Subject != "user-1" || identity.Username != "analyst" {
+		t.Errorf("Unexpected identity: %+v", identity)
+	}
+	if !identity.HasRole("viewer") || !identity.HasRole(AdminRole) {
+		t.Errorf("Expected realm and client roles, got %v", identity.Roles)
+	}
+	if identity.HasRole("owner") {
+		t.Error("Expected roles of other clients to be ignored")
+	}
+
+	if _, ok := FromContext(Verified(context.Background()), "geovision"); ok {
+		t.Error("Expected no identity without metadata")
+	}
+
+	var missing *Identity
+	if missing.HasRole(AdminRole) {
+		t.Error("Expected nil identity to have no roles")
+	}
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestInterceptors(t *testing.T) {
+	// Stands in for the shared middleware, rejecting forged tokens
+	identity := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			if value != token(`{"sub": "user-1"}`) {
+				return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+			}
+		}
+		return handler(ctx, req)
+	}
+	unary, stream := Interceptors(identity)
+	info := &grpc.StreamServerInfo{FullMethod: "/geovision.v1.GeoService/ExportEvents"}
+	withToken := func(payload string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", token(payload)))
+	}
+
+	t.Run("Unary", func(t *testing.T) {
+		var subject string
+		_, err := unary(withToken(`{"sub": "user-1"}`), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			if identity, ok := FromContext(ctx, "geovision"); ok {
+				subject = identity.Subject
+			}
+			return nil, nil
+		})
+		if err != nil || subject != "user-1" {
+			t.Errorf("Expected the verified subject, got %q and %v", subject, err)
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		var subject string
+		err := stream(nil, &testStream{ctx: withToken(`{"sub": "user-1"}`)}, info, func(srv interface{}, stream grpc.ServerStream) error {
+			if identity, ok := FromContext(stream.Context(), "geovision"); ok {
+				subject = identity.Subject
+			}
+			return nil
+		})
+		if err != nil || subject != "user-1" {
+			t.Errorf("Expected the verified subject, got %q and %v", subject, err)
+		}
+	})
+
+	t.Run("Forged Stream", func(t *testing.T) {
+		called := false
+		err := stream(nil, &testStream{ctx: withToken(`{"sub": "user-2", "realm_access": {"roles": ["admin"]}}`)}, info, func(srv interface{}, stream grpc.ServerStream) error {
+			called = true
+			return nil
+		})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Expected Unauthenticated, got %v", err)
+		}
+		if called {
+			t.Error("Expected the handler not to run")
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Eviction", func(t *testing.T) {
+		c := NewLRU(2)
+		c.Set(ctx, "a", []byte("1"), time.Minute)
+		c.Set(ctx, "b", []byte("2"), time.Minute)
+		// Reading a makes b the least recently used
+		if value, ok, _ := c.Get(ctx, "a"); !ok || string(value) != "1" {
+			t.Fatalf("Expected a to be cached, got %q", value)
+		}
+		c.Set(ctx, "c", []byte("3"), time.Minute)
+
+		if _, ok, _ := c.Get(ctx, "b"); ok {
+			t.Error("Expected b to be evicted")
+		}
+		if _, ok, _ := c.Get(ctx, "a"); !ok {
+			t.Error("Expected a to be kept")
+		}
+		if c.Len() != 2 {
+			t.Errorf("Expected 2 entries, got %d", c.Len())
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		now := time.Unix(1000, 0)
+		c := NewLRU(10)
+		c.now = func() time.Time { return now }
+		c.Set(ctx, "a", []byte("1"), time.Second)
+
+		if _, ok, _ := c.Get(ctx, "a"); !ok {
+			t.Error("Expected a to be cached before its TTL")
+		}
+		now = now.Add(time.Second)
+		if _, ok, _ := c.Get(ctx, "a"); ok {
+			t.Error("Expected a to expire after its TTL")
+		}
+		if c.Len() != 0 {
+			t.Errorf("Expected the expired entry to be dropped, got %d entries", c.Len())
+		}
+	})
+}
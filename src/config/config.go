@@ -0,0 +1,537 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/geocoding"
+	"github.com/omnsight/geovision/src/lifecycle"
+	"github.com/omnsight/geovision/src/ratelimit"
+	"github.com/omnsight/geovision/src/services"
+	"github.com/omnsight/geovision/src/tak"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// Path is the environment variable naming the configuration file, unless
+// given with the --config flag.
+const Path = "GEOVISION_CONFIG"
+
+// Bounds of the query limits.
+const (
+	maxGraphDepth      = 5
+	maxResults         = 1000
+	maxCentralityNodes = 100000
+)
+
+// defaultMaxMessageSize bounds the requests the gRPC server accepts, which
+// carry whole files for the imports.
+const defaultMaxMessageSize = 64 << 20
+
+// Config is the configuration of the server. Each field may be set in the
+// configuration file under its yaml or toml name, and is overridden by the
+// environment variable in its env tag when that is set. Where the tag lists
+// several variables, the first one set wins.
+type Config struct {
+	GRPCPort        string   `yaml:"grpc_port" toml:"grpc_port" env:"GRPC_PORT"`
+	ServerPort      string   `yaml:"server_port" toml:"server_port" env:"SERVER_PORT"`
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	// MaxMessageSize is the largest request, in bytes, such as an uploaded
+	// file, the gRPC server accepts
+	MaxMessageSize int `yaml:"max_message_size" toml:"max_message_size" env:"GRPC_MAX_MESSAGE_SIZE"`
+
+	Keycloak  Keycloak  `yaml:"keycloak" toml:"keycloak"`
+	Query     Query     `yaml:"query" toml:"query"`
+	Cache     Cache     `yaml:"cache" toml:"cache"`
+	RateLimit RateLimit `yaml:"rate_limit" toml:"rate_limit"`
+	Audit     Audit     `yaml:"audit" toml:"audit"`
+	Geocoding Geocoding `yaml:"geocoding" toml:"geocoding"`
+	CoT       CoT       `yaml:"cot" toml:"cot"`
+	Tracing   Tracing   `yaml:"tracing" toml:"tracing"`
+}
+
+// Keycloak identifies the client whose roles are checked for admin RPCs.
+type Keycloak struct {
+	ClientID string `yaml:"client_id" toml:"client_id" env:"KEYCLOAK_CLIENT_ID"`
+}
+
+// Query caps what a single request may ask for and the resources it may
+// use.
+type Query struct {
+	MaxGraphDepth      int `yaml:"max_graph_depth" toml:"max_graph_depth" env:"QUERY_MAX_GRAPH_DEPTH"`
+	MaxCentralityNodes int `yaml:"max_centrality_nodes" toml:"max_centrality_nodes" env:"QUERY_MAX_CENTRALITY_NODES"`
+	MaxResults         int `yaml:"max_results" toml:"max_results" env:"QUERY_MAX_RESULTS"`
+	// Timeout is the deadline of unary RPCs, and Timeouts that of single
+	// RPCs by name, which is the only way to give streaming RPCs one
+	Timeout  Duration            `yaml:"timeout" toml:"timeout" env:"QUERY_TIMEOUT"`
+	Timeouts map[string]Duration `yaml:"timeouts" toml:"timeouts"`
+	// MaxRuntime and MemoryLimit, in bytes, bound every AQL query on the
+	// server
+	MaxRuntime  Duration `yaml:"max_runtime" toml:"max_runtime" env:"QUERY_MAX_RUNTIME"`
+	MemoryLimit int      `yaml:"memory_limit" toml:"memory_limit" env:"QUERY_MEMORY_LIMIT"`
+}
+
+// Deadlines returns the deadlines of the RPCs.
+func (q Query) Deadlines() services.Deadlines {
+	deadlines := services.Deadlines{
+		Default: time.Duration(q.Timeout),
+		Methods: make(map[string]time.Duration, len(q.Timeouts)),
+	}
+	for name, timeout := range q.Timeouts {
+		deadlines.Methods[name] = time.Duration(timeout)
+	}
+	return deadlines
+}
+
+// Cache configures the query cache in front of the event queries.
+type Cache struct {
+	Enabled bool     `yaml:"enabled" toml:"enabled" env:"CACHE_ENABLED"`
+	TTL     Duration `yaml:"ttl" toml:"ttl" env:"CACHE_TTL"`
+	Window  Duration `yaml:"window" toml:"window" env:"CACHE_WINDOW"`
+	// Size is the number of entries of the in-process cache
+	Size int `yaml:"size" toml:"size" env:"CACHE_SIZE"`
+	// RedisURL, when set, shares the cache between replicas through a
+	// Redis-compatible server instead
+	RedisURL Secret `yaml:"redis_url" toml:"redis_url" env:"CACHE_REDIS_URL"`
+}
+
+// RateLimit throttles each caller with a token bucket per RPC. Callers are
+// told apart by the subject of their token, or by their address without one.
+type RateLimit struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// Rate is the requests per second a caller may make to each RPC, up to
+	// Burst of them at once
+	Rate  float64 `yaml:"rate" toml:"rate" env:"RATE_LIMIT_RATE"`
+	Burst int     `yaml:"burst" toml:"burst" env:"RATE_LIMIT_BURST"`
+	// Methods holds the limits of single RPCs by name
+	Methods map[string]Limit `yaml:"methods" toml:"methods"`
+	// Roles holds the limits of callers with a role, the most generous one
+	// winning
+	Roles map[string]RoleLimit `yaml:"roles" toml:"roles"`
+	// TrustedProxies are the addresses or CIDR ranges whose X-Forwarded-For
+	// header is believed when telling the address of HTTP clients
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+}
+
+// Limit is a token bucket of a rate limit.
+type Limit struct {
+	Rate  float64 `yaml:"rate" toml:"rate"`
+	Burst int     `yaml:"burst" toml:"burst"`
+}
+
+// RoleLimit replaces the limits for callers with a role. A zero rate keeps
+// the general limit of the RPCs not in Methods.
+type RoleLimit struct {
+	Rate    float64          `yaml:"rate" toml:"rate"`
+	Burst   int              `yaml:"burst" toml:"burst"`
+	Methods map[string]Limit `yaml:"methods" toml:"methods"`
+}
+
+// Policies returns the general rate limits and those of each role.
+func (r RateLimit) Policies() (ratelimit.Policy, map[string]ratelimit.Policy) {
+	policy := func(rate float64, burst int, methods map[string]Limit) ratelimit.Policy {
+		p := ratelimit.Policy{
+			Default: ratelimit.Limit{Rate: rate, Burst: burst},
+			Methods: make(map[string]ratelimit.Limit, len(methods)),
+		}
+		for name, limit := range methods {
+			p.Methods[name] = ratelimit.Limit{Rate: limit.Rate, Burst: limit.Burst}
+		}
+		return p
+	}
+
+	roles := make(map[string]ratelimit.Policy, len(r.Roles))
+	for role, limit := range r.Roles {
+		roles[role] = policy(limit.Rate, limit.Burst, limit.Methods)
+	}
+	return policy(r.Rate, r.Burst, r.Methods), roles
+}
+
+// Audit configures the audit log of GeoService calls.
+type Audit struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"AUDIT_ENABLED"`
+	// Collection is the ArangoDB collection the entries are written to
+	Collection string `yaml:"collection" toml:"collection" env:"AUDIT_COLLECTION"`
+	// File, when set, also appends the entries to this file as JSON lines
+	File string `yaml:"file" toml:"file" env:"AUDIT_FILE"`
+}
+
+// Geocoding enables reverse geocoding of event locations and forward
+// geocoding of place names. Either is disabled with a warning when its files
+// cannot be loaded.
+type Geocoding struct {
+	Reverse       bool   `yaml:"reverse" toml:"reverse" env:"REVERSE_GEOCODING_ENABLED"`
+	Admin0Path    string `yaml:"admin0_path" toml:"admin0_path" env:"REVERSE_GEOCODING_ADMIN0_PATH"`
+	Admin1Path    string `yaml:"admin1_path" toml:"admin1_path" env:"REVERSE_GEOCODING_ADMIN1_PATH"`
+	Forward       bool   `yaml:"forward" toml:"forward" env:"FORWARD_GEOCODING_ENABLED"`
+	GazetteerPath string `yaml:"gazetteer_path" toml:"gazetteer_path" env:"GAZETTEER_PATH"`
+}
+
+// CoT configures the Cursor-on-Target feed, which runs when the TCP port or
+// UDP destinations are set. Only events up to MaxSensitivity, inside Bbox and
+// younger than MaxAge are pushed.
+type CoT struct {
+	TCPPort string `yaml:"tcp_port" toml:"tcp_port" env:"COT_TCP_PORT"`
+	// TLSCert and TLSKey serve the TCP feed over TLS; with ClientCA, clients
+	// must present a certificate signed by it
+	TLSCert  string `yaml:"tls_cert" toml:"tls_cert" env:"COT_TLS_CERT"`
+	TLSKey   string `yaml:"tls_key" toml:"tls_key" env:"COT_TLS_KEY"`
+	ClientCA string `yaml:"client_ca" toml:"client_ca" env:"COT_CLIENT_CA"`
+	// AllowedNetworks are the addresses or CIDR ranges TCP clients may
+	// connect from
+	AllowedNetworks []string `yaml:"allowed_networks" toml:"allowed_networks"`
+	// UDPDestinations are the host:port addresses datagrams are sent to
+	UDPDestinations []string `yaml:"udp_destinations" toml:"udp_destinations"`
+	PollInterval    Duration `yaml:"poll_interval" toml:"poll_interval" env:"COT_POLL_INTERVAL"`
+	// MaxSensitivity is the name of the highest sensitivity pushed
+	MaxSensitivity string `yaml:"max_sensitivity" toml:"max_sensitivity" env:"COT_MAX_SENSITIVITY"`
+	// Bbox is the minimum latitude and longitude and the maximum latitude and
+	// longitude of the area pushed
+	Bbox   []float64 `yaml:"bbox" toml:"bbox"`
+	MaxAge Duration  `yaml:"max_age" toml:"max_age" env:"COT_MAX_AGE"`
+}
+
+// Enabled tells whether the feed should run.
+func (c CoT) Enabled() bool {
+	return c.TCPPort != "" || len(c.UDPDestinations) > 0
+}
+
+// Options returns the filters of the feed and the networks allowed to
+// connect.
+func (c CoT) Options() tak.Options {
+	opts := tak.Options{
+		MaxSensitivity: model.Sensitivity(model.Sensitivity_value[c.MaxSensitivity]),
+		MaxAge:         time.Duration(c.MaxAge),
+	}
+	if len(c.Bbox) == 4 {
+		opts.Bbox = &geovision.BoundingBox{
+			MinLatitude:  c.Bbox[0],
+			MinLongitude: c.Bbox[1],
+			MaxLatitude:  c.Bbox[2],
+			MaxLongitude: c.Bbox[3],
+		}
+	}
+	for _, network := range c.AllowedNetworks {
+		if ip := net.ParseIP(network); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			opts.Allowed = append(opts.Allowed, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		} else if _, ipNet, err := net.ParseCIDR(network); err == nil {
+			opts.Allowed = append(opts.Allowed, ipNet)
+		}
+	}
+	return opts
+}
+
+// Tracing configures the OTLP/gRPC trace exporter, which runs when an
+// endpoint is set. The exporter reads its other settings, such as
+// OTEL_EXPORTER_OTLP_INSECURE, from the environment itself.
+type Tracing struct {
+	Endpoint string `yaml:"endpoint" toml:"endpoint" env:"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT,OTEL_EXPORTER_OTLP_ENDPOINT"`
+	// Headers are sent with every export, as comma separated key=value
+	// pairs. They usually carry credentials.
+	Headers Secret `yaml:"headers" toml:"headers" env:"OTEL_EXPORTER_OTLP_TRACES_HEADERS,OTEL_EXPORTER_OTLP_HEADERS"`
+}
+
+// HeaderMap parses the headers.
+func (t Tracing) HeaderMap() (map[string]string, error) {
+	headers := make(map[string]string)
+	if t.Headers == "" {
+		return headers, nil
+	}
+	for _, pair := range strings.Split(string(t.Headers), ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, errors.New("headers must be comma separated key=value pairs")
+		}
+		value, err := url.QueryUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %v", key, err)
+		}
+		headers[key] = value
+	}
+	return headers, nil
+}
+
+// Default returns the configuration used for everything that is not set.
+func Default() *Config {
+	return &Config{
+		ShutdownTimeout: Duration(lifecycle.DefaultShutdownTimeout),
+		MaxMessageSize:  defaultMaxMessageSize,
+		Query: Query{
+			MaxGraphDepth:      services.DefaultMaxGraphDepth,
+			MaxCentralityNodes: services.DefaultMaxCentralityNodes,
+			MaxResults:         services.DefaultMaxResults,
+			Timeout:            Duration(services.DefaultRPCTimeout),
+			MaxRuntime:         Duration(services.DefaultQueryMaxRuntime),
+			MemoryLimit:        services.DefaultQueryMemoryLimit,
+		},
+		Cache: Cache{
+			TTL:    Duration(30 * time.Second),
+			Window: Duration(time.Minute),
+			Size:   1000,
+		},
+		RateLimit: RateLimit{
+			Rate:  10,
+			Burst: 20,
+		},
+		Audit: Audit{
+			Enabled:    true,
+			Collection: "audit",
+		},
+		Geocoding: Geocoding{
+			Reverse:       true,
+			Admin0Path:    geocoding.DefaultAdmin0BoundariesPath,
+			Admin1Path:    geocoding.DefaultAdmin1BoundariesPath,
+			Forward:       true,
+			GazetteerPath: geocoding.DefaultGazetteerPath,
+		},
+		CoT: CoT{
+			PollInterval:   Duration(tak.DefaultPollInterval),
+			MaxSensitivity: model.Sensitivity_SENSITIVITY_PUBLIC_UNSPECIFIED.String(),
+			MaxAge:         Duration(24 * time.Hour),
+		},
+	}
+}
+
+// Validate checks the whole configuration and reports every problem found,
+// not only the first.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	ports := make(map[string]string)
+	checkPort := func(name, port string, required bool) {
+		if port == "" {
+			if required {
+				invalid("%s is required", name)
+			}
+			return
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			invalid("%s must be a port between 1 and 65535, got %q", name, port)
+			return
+		}
+		if other, ok := ports[port]; ok {
+			invalid("%s and %s both use port %s", other, name, port)
+		}
+		ports[port] = name
+	}
+	checkPort("grpc_port", c.GRPCPort, true)
+	checkPort("server_port", c.ServerPort, true)
+	checkPort("cot.tcp_port", c.CoT.TCPPort, false)
+
+	if c.ShutdownTimeout <= 0 {
+		invalid("shutdown_timeout must be positive")
+	}
+	if c.MaxMessageSize < 4<<20 {
+		invalid("max_message_size must be at least 4194304 bytes")
+	}
+	if c.Keycloak.ClientID == "" {
+		invalid("keycloak.client_id is required")
+	}
+
+	if c.Query.MaxGraphDepth < 1 || c.Query.MaxGraphDepth > maxGraphDepth {
+		invalid("query.max_graph_depth must be between 1 and %d", maxGraphDepth)
+	}
+	if c.Query.MaxCentralityNodes < 1 || c.Query.MaxCentralityNodes > maxCentralityNodes {
+		invalid("query.max_centrality_nodes must be between 1 and %d", maxCentralityNodes)
+	}
+	if c.Query.MaxResults < 1 || c.Query.MaxResults > maxResults {
+		invalid("query.max_results must be between 1 and %d", maxResults)
+	}
+	if c.Query.Timeout <= 0 {
+		invalid("query.timeout must be positive")
+	}
+	for name, timeout := range c.Query.Timeouts {
+		if !isRPC(name) {
+			invalid("query.timeouts: unknown RPC %s", name)
+		} else if timeout <= 0 {
+			invalid("query.timeouts: timeout of %s must be positive", name)
+		}
+	}
+	if c.Query.MaxRuntime <= 0 {
+		invalid("query.max_runtime must be positive")
+	}
+	if c.Query.MemoryLimit < 1<<20 {
+		invalid("query.memory_limit must be at least 1048576 bytes")
+	}
+
+	if c.Cache.Enabled {
+		if c.Cache.TTL <= 0 {
+			invalid("cache.ttl must be positive")
+		}
+		if c.Cache.Window < 0 || (c.Cache.Window > 0 && c.Cache.Window < Duration(time.Second)) {
+			invalid("cache.window must be zero or at least 1s")
+		}
+		if c.Cache.RedisURL == "" && c.Cache.Size < 1 {
+			invalid("cache.size must be positive")
+		}
+		if c.Cache.RedisURL != "" {
+			if u, err := url.Parse(string(c.Cache.RedisURL)); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+				invalid("cache.redis_url must be a redis:// or rediss:// URL")
+			}
+		}
+	}
+
+	if c.RateLimit.Enabled {
+		checkLimit := func(name string, limit Limit) {
+			if limit.Rate <= 0 || limit.Burst < 1 {
+				invalid("%s needs a positive rate and burst", name)
+			}
+		}
+		checkMethods := func(name string, methods map[string]Limit) {
+			for method, limit := range methods {
+				if !isRPC(method) {
+					invalid("%s: unknown RPC %s", name, method)
+				} else {
+					checkLimit(name+"."+method, limit)
+				}
+			}
+		}
+		checkLimit("rate_limit", Limit{Rate: c.RateLimit.Rate, Burst: c.RateLimit.Burst})
+		checkMethods("rate_limit.methods", c.RateLimit.Methods)
+		for role, limit := range c.RateLimit.Roles {
+			name := "rate_limit.roles." + role
+			if limit.Rate != 0 || limit.Burst != 0 {
+				checkLimit(name, Limit{Rate: limit.Rate, Burst: limit.Burst})
+			}
+			checkMethods(name+".methods", limit.Methods)
+		}
+		for _, proxy := range c.RateLimit.TrustedProxies {
+			if net.ParseIP(proxy) == nil {
+				if _, _, err := net.ParseCIDR(proxy); err != nil {
+					invalid("rate_limit.trusted_proxies: %q is not an address or CIDR range", proxy)
+				}
+			}
+		}
+	}
+
+	if c.Audit.Enabled && c.Audit.Collection == "" {
+		invalid("audit.collection is required")
+	}
+
+	if c.Geocoding.Reverse && c.Geocoding.Admin0Path == "" {
+		invalid("geocoding.admin0_path is required for reverse geocoding")
+	}
+	if c.Geocoding.Forward && c.Geocoding.GazetteerPath == "" {
+		invalid("geocoding.gazetteer_path is required for forward geocoding")
+	}
+
+	if c.CoT.Enabled() && c.CoT.PollInterval <= 0 {
+		invalid("cot.poll_interval must be positive")
+	}
+	// The feed pushes events to anyone who can reach it, so TCP clients must
+	// be authenticated by certificate or come from known networks
+	if c.CoT.TCPPort != "" && c.CoT.ClientCA == "" && len(c.CoT.AllowedNetworks) == 0 {
+		invalid("cot.tcp_port requires cot.client_ca or cot.allowed_networks")
+	}
+	if (c.CoT.TLSCert == "") != (c.CoT.TLSKey == "") {
+		invalid("cot.tls_cert and cot.tls_key must be set together")
+	}
+	if c.CoT.ClientCA != "" && c.CoT.TLSCert == "" {
+		invalid("cot.client_ca requires cot.tls_cert and cot.tls_key")
+	}
+	for _, network := range c.CoT.AllowedNetworks {
+		if net.ParseIP(network) == nil {
+			if _, _, err := net.ParseCIDR(network); err != nil {
+				invalid("cot.allowed_networks: %q is not an address or CIDR range", network)
+			}
+		}
+	}
+	for _, destination := range c.CoT.UDPDestinations {
+		if _, port, err := net.SplitHostPort(destination); err != nil || port == "" {
+			invalid("cot.udp_destinations: %q is not a host:port address", destination)
+		}
+	}
+	if _, ok := model.Sensitivity_value[c.CoT.MaxSensitivity]; !ok {
+		invalid("cot.max_sensitivity must be a sensitivity such as %s, got %q", model.Sensitivity_SENSITIVITY_PUBLIC_UNSPECIFIED, c.CoT.MaxSensitivity)
+	}
+	if len(c.CoT.Bbox) != 0 {
+		if len(c.CoT.Bbox) != 4 || c.CoT.Bbox[0] > c.CoT.Bbox[2] || c.CoT.Bbox[1] > c.CoT.Bbox[3] {
+			invalid("cot.bbox must be [min_latitude, min_longitude, max_latitude, max_longitude]")
+		}
+	}
+	if c.CoT.MaxAge < 0 {
+		invalid("cot.max_age must not be negative")
+	}
+
+	if c.Tracing.Endpoint != "" {
+		if u, err := url.Parse(c.Tracing.Endpoint); err != nil || u.Host == "" {
+			invalid("tracing.endpoint must be a URL such as http://collector:4317")
+		}
+	}
+	if _, err := c.Tracing.HeaderMap(); err != nil {
+		invalid("tracing.headers: %v", err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// isRPC tells whether name is an RPC of the GeoService.
+func isRPC(name string) bool {
+	for _, method := range geovision.GeoService_ServiceDesc.Methods {
+		if method.MethodName == name {
+			return true
+		}
+	}
+	for _, stream := range geovision.GeoService_ServiceDesc.Streams {
+		if stream.StreamName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Duration is a time.Duration written as text, such as 15s or 1m30s.
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	value, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(value)
+	return nil
+}
+
+// masked replaces secrets when the configuration is printed.
+const masked = "********"
+
+// Secret is a string that is masked whenever it is marshalled or printed.
+type Secret string
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Secret) UnmarshalText(text []byte) error {
+	*s = Secret(text)
+	return nil
+}
+
+// String masks the secret, leaving empty secrets empty.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return masked
+}
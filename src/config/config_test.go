@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Expected to write the config file, got %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		path := writeConfig(t, "geovision.yaml", `
+grpc_port: "50051"
+server_port: "8080"
+shutdown_timeout: 30s
+keycloak:
+  client_id: geovision
+query:
+  max_graph_depth: 2
+cot:
+  tcp_port: "8087"
+  allowed_networks: [10.0.0.0/8]
+`)
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if cfg.GRPCPort != "50051" || cfg.Keycloak.ClientID != "geovision" {
+			t.Errorf("Expected the file values, got %+v", cfg)
+		}
+		if time.Duration(cfg.ShutdownTimeout) != 30*time.Second {
+			t.Errorf("Expected a 30s shutdown timeout, got %v", time.Duration(cfg.ShutdownTimeout))
+		}
+		if cfg.Query.MaxGraphDepth != 2 || cfg.Query.MaxResults != Default().Query.MaxResults {
+			t.Errorf("Expected the file depth and the default result limit, got %+v", cfg.Query)
+		}
+		if !cfg.CoT.Enabled() || cfg.CoT.PollInterval != Default().CoT.PollInterval {
+			t.Errorf("Expected the CoT feed enabled with the default interval, got %+v", cfg.CoT)
+		}
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		path := writeConfig(t, "geovision.toml", `
+grpc_port = "50051"
+server_port = "8080"
+
+[keycloak]
+client_id = "geovision"
+
+[geocoding]
+forward = false
+`)
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if cfg.ServerPort != "8080" || cfg.Geocoding.Forward || !cfg.Geocoding.Reverse {
+			t.Errorf("Expected the file values over the defaults, got %+v", cfg)
+		}
+	})
+
+	t.Run("Timeouts", func(t *testing.T) {
+		path := writeConfig(t, "geovision.yaml", `
+grpc_port: "50051"
+server_port: "8080"
+keycloak:
+  client_id: geovision
+query:
+  timeout: 10s
+  timeouts:
+    ExportEvents: 5m
+`)
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		deadlines := cfg.Query.Deadlines()
+		if deadlines.Default != 10*time.Second || deadlines.Methods["ExportEvents"] != 5*time.Minute {
+			t.Errorf("Expected the file timeouts, got %+v", deadlines)
+		}
+
+		path = writeConfig(t, "geovision.yaml", `
+grpc_port: "50051"
+server_port: "8080"
+keycloak:
+  client_id: geovision
+query:
+  timeouts:
+    GetEvent: 5s
+`)
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "unknown RPC GetEvent") {
+			t.Errorf("Expected an error for a misspelled RPC, got %v", err)
+		}
+	})
+
+	t.Run("Unknown Key", func(t *testing.T) {
+		path := writeConfig(t, "geovision.yaml", "grpc_prot: \"50051\"\n")
+		if _, err := Load(path); err == nil {
+			t.Errorf("Expected an error for a misspelled key")
+		}
+	})
+
+	t.Run("Environment Overrides", func(t *testing.T) {
+		path := writeConfig(t, "geovision.yaml", "grpc_port: \"50051\"\nserver_port: \"8080\"\n")
+		t.Setenv("SERVER_PORT", "9090")
+		t.Setenv("KEYCLOAK_CLIENT_ID", "geovision")
+		t.Setenv("QUERY_MAX_RESULTS", "50")
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4317")
+
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if cfg.GRPCPort != "50051" || cfg.ServerPort != "9090" {
+			t.Errorf("Expected the environment to override the file, got %s and %s", cfg.GRPCPort, cfg.ServerPort)
+		}
+		if cfg.Query.MaxResults != 50 || cfg.Tracing.Endpoint != "http://collector:4317" {
+			t.Errorf("Expected the environment values, got %+v and %+v", cfg.Query, cfg.Tracing)
+		}
+	})
+
+	t.Run("All Errors", func(t *testing.T) {
+		t.Setenv("GRPC_PORT", "8080")
+		t.Setenv("SERVER_PORT", "8080")
+		t.Setenv("QUERY_MAX_GRAPH_DEPTH", "deep")
+		t.Setenv("QUERY_MAX_CENTRALITY_NODES", "0")
+		t.Setenv("COT_TCP_PORT", "8087")
+		t.Setenv("RATE_LIMIT_ENABLED", "true")
+		t.Setenv("RATE_LIMIT_RATE", "0")
+
+		_, err := Load("")
+		if err == nil {
+			t.Fatalf("Expected an error")
+		}
+		for _, want := range []string{
+			"QUERY_MAX_GRAPH_DEPTH: invalid integer",
+			"grpc_port and server_port both use port 8080",
+			"query.max_centrality_nodes must be between 1 and 100000",
+			"keycloak.client_id is required",
+			"cot.tcp_port requires cot.client_ca or cot.allowed_networks",
+			"rate_limit needs a positive rate and burst",
+		} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected %q in %v", want, err)
+			}
+		}
+	})
+}
+
+func TestPrint(t *testing.T) {
+	cfg := Default()
+	cfg.GRPCPort = "50051"
+	cfg.Tracing.Headers = "authorization=Bearer%20token"
+
+	var buf bytes.Buffer
+	if err := cfg.Print(&buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Contains(buf.String(), "token") {
+		t.Errorf("Expected the headers to be masked, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), masked) || !strings.Contains(buf.String(), "shutdown_timeout: 15s") {
+		t.Errorf("Expected the masked secret and the readable timeout, got %s", buf.String())
+	}
+
+	headers, err := cfg.Tracing.HeaderMap()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := map[string]string{"authorization": "Bearer token"}; !reflect.DeepEqual(headers, want) {
+		t.Errorf("Expected %v, got %v", want, headers)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Load reads the configuration file at path, if any, over the defaults,
+// applies the environment overrides and validates the result. All problems
+// are reported together.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := decode(path, data, cfg); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+
+	errs := applyEnv(reflect.ValueOf(cfg).Elem(), os.LookupEnv)
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+
+// decode picks the format from the file extension and rejects unknown keys,
+// which are most likely typos.
+func decode(path string, data []byte, cfg *Config) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return yaml.UnmarshalWithOptions(data, cfg, yaml.Strict())
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(cfg)
+	}
+	return errors.New("unsupported configuration format, expected .yaml, .yml or .toml")
+}
+
+// applyEnv overrides the fields of v that have an env tag with the first of
+// its variables that is set, recursing into nested sections.
+func applyEnv(v reflect.Value, lookup func(string) (string, bool)) []error {
+	var errs []error
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+
+		tag := field.Tag.Get("env")
+		if tag == "" {
+			if value.Kind() == reflect.Struct {
+				errs = append(errs, applyEnv(value, lookup)...)
+			}
+			continue
+		}
+
+		for _, name := range strings.Split(tag, ",") {
+			text, ok := lookup(name)
+			if !ok {
+				continue
+			}
+			if err := setText(value, text); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", name, err))
+			}
+			break
+		}
+	}
+	return errs
+}
+
+func setText(value reflect.Value, text string) error {
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(text))
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Int:
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", text)
+		}
+		value.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", text)
+		}
+		value.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", text)
+		}
+		value.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// Print writes the effective configuration as YAML, with secrets masked.
+func (c *Config) Print(w io.Writer) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// CotType is the CoT type of exported events, an unknown ground atom.
+const CotType = "a-u-G"
+
+// DefaultCotStale is how long TAK clients keep showing an exported event.
+const DefaultCotStale = 24 * time.Hour
+
+// cotUnknown is the CoT value for unknown elevation and error.
+const cotUnknown = 9999999.0
+
+// cotTimeFormat is the timestamp format CoT expects.
+const cotTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// CotEvent is a Cursor-on-Target event message.
+type CotEvent struct {
+	XMLName xml.Name  `xml:"event"`
+	Version string    `xml:"version,attr"`
+	UID     string    `xml:"uid,attr"`
+	Type    string    `xml:"type,attr"`
+	How     string    `xml:"how,attr"`
+	Time    string    `xml:"time,attr"`
+	Start   string    `xml:"start,attr"`
+	Stale   string    `xml:"stale,attr"`
+	Point   cotPoint  `xml:"point"`
+	Detail  cotDetail `xml:"detail"`
+}
+
+type cotPoint struct {
+	Lat float64 `xml:"lat,attr"`
+	Lon float64 `xml:"lon,attr"`
+	Hae float64 `xml:"hae,attr"`
+	Ce  float64 `xml:"ce,attr"`
+	Le  float64 `xml:"le,attr"`
+}
+
+type cotDetail struct {
+	Contact cotContact `xml:"contact"`
+	Remarks string     `xml:"remarks,omitempty"`
+}
+
+type cotContact struct {
+	Callsign string `xml:"callsign,attr"`
+}
+
+// NewCotEvent converts an event into a CoT message generated at now and stale
+// after the given duration. Events without a location cannot be placed on a
+// map and are skipped.
+func NewCotEvent(event *model.Event, now time.Time, stale time.Duration) (*CotEvent, bool) {
+	p, ok := geo.EventPoint(event)
+	if !ok {
+		return nil, false
+	}
+
+	start := now
+	if event.GetHappenedAt() != 0 {
+		start = time.Unix(event.GetHappenedAt(), 0)
+	}
+
+	return &CotEvent{
+		Version: "2.0",
+		UID:     "geovision-" + xmlID(event.GetId()),
+		Type:    CotType,
+		How:     "h-e",
+		Time:    now.UTC().Format(cotTimeFormat),
+		Start:   start.UTC().Format(cotTimeFormat),
+		Stale:   now.Add(stale).UTC().Format(cotTimeFormat),
+		Point:   cotPoint{Lat: p.Latitude, Lon: p.Longitude, Hae: cotUnknown, Ce: cotUnknown, Le: cotUnknown},
+		Detail: cotDetail{
+			Contact: cotContact{Callsign: event.GetTitle()},
+			Remarks: event.GetDescription(),
+		},
+	}, true
+}
+
+// MarshalCot encodes an event as a standalone CoT message.
+func MarshalCot(event *CotEvent) ([]byte, error) {
+	data, err := xml.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// WriteCot writes one CoT message per located event, one after the other, as
+// TAK clients expect on a stream.
+func WriteCot(w io.Writer, resp *geovision.GetEventsResponse, stale time.Duration) error {
+	now := time.Now()
+	for _, event := range resp.GetEvents() {
+		cot, ok := NewCotEvent(event, now, stale)
+		if !ok {
+			continue
+		}
+		data, err := MarshalCot(cot)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
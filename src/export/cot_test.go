@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCotEvent(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := testResponse().GetEvents()
+
+	cot, ok := NewCotEvent(events[0], now, time.Hour)
+	if !ok {
+		t.Fatal("Expected located event to convert")
+	}
+	if cot.Point.Lat != 50 || cot.Point.Lon != 36.25 {
+		t.Errorf("Unexpected point: %+v", cot.Point)
+	}
+	if cot.Time != "2024-01-01T12:00:00.000Z" || cot.Stale != "2024-01-01T13:00:00.000Z" {
+		t.Errorf("Unexpected times: %s %s", cot.Time, cot.Stale)
+	}
+	if cot.Start != "2023-11-14T22:13:20.000Z" {
+		t.Errorf("Expected start at happened_at, got %s", cot.Start)
+	}
+	if cot.Detail.Contact.Callsign != "Strike <A>" {
+		t.Errorf("Expected title as callsign, got %s", cot.Detail.Contact.Callsign)
+	}
+
+	if _, ok := NewCotEvent(events[2], now, time.Hour); ok {
+		t.Error("Expected unlocated event to be skipped")
+	}
+}
+
+func TestWriteCot(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCot(&buf, testResponse(), time.Hour); err != nil {
+		t.Fatalf("Failed to write CoT: %v", err)
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(buf.String()))
+	count := 0
+	for {
+		var event CotEvent
+		if err := decoder.Decode(&event); err != nil {
+			break
+		}
+		if event.Version != "2.0" || event.Type != CotType {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 CoT messages, got %d", count)
+	}
+}
@@ -0,0 +1,272 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/omnsight/geovision/src/graph"
+)
+
+// Content types of the graph exports.
+const (
+	GraphMLContentType = "application/graphml+xml"
+	GEXFContentType    = "application/gexf+xml"
+)
+
+type graphML struct {
+	XMLName xml.Name     `xml:"http://graphml.graphdrawing.org/xmlns graphml"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph as GraphML. Nodes carry their collection, label
+// and every attribute of the document; edges their name, confidence and
+// attributes.
+func WriteGraphML(w io.Writer, g *graph.Graph) error {
+	doc := graphML{Graph: graphMLGraph{ID: "events", EdgeDefault: "directed"}}
+
+	nodeKeys := withFixedKeys(g.NodeAttributes(),
+		graph.AttributeKey{Name: "collection", Type: graph.TypeString},
+		graph.AttributeKey{Name: "label", Type: graph.TypeString},
+	)
+	edgeKeys := withFixedKeys(g.EdgeAttributes(),
+		graph.AttributeKey{Name: "label", Type: graph.TypeString},
+		graph.AttributeKey{Name: "confidence", Type: graph.TypeInt},
+	)
+
+	for i, key := range nodeKeys {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: fmt.Sprintf("n%d", i), For: "node", Name: key.Name, Type: key.Type})
+	}
+	for i, key := range edgeKeys {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: fmt.Sprintf("e%d", i), For: "edge", Name: key.Name, Type: key.Type})
+	}
+
+	for _, node := range g.Nodes {
+		attributes := withFixed(node.Attributes, map[string]interface{}{"collection": node.Collection, "label": node.Label})
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID:   node.ID,
+			Data: graphMLValues("n", nodeKeys, attributes),
+		})
+	}
+	for _, edge := range g.Edges {
+		attributes := withFixed(edge.Attributes, map[string]interface{}{"label": edge.Name, "confidence": float64(edge.Confidence)})
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     edge.ID,
+			Source: edge.From,
+			Target: edge.To,
+			Data:   graphMLValues("e", edgeKeys, attributes),
+		})
+	}
+
+	return writeXML(w, doc)
+}
+
+func graphMLValues(prefix string, keys []graph.AttributeKey, attributes map[string]interface{}) []graphMLData {
+	var data []graphMLData
+	for i, key := range keys {
+		if value, ok := attributes[key.Name]; ok {
+			data = append(data, graphMLData{Key: fmt.Sprintf("%s%d", prefix, i), Value: formatAttribute(value, key.Type)})
+		}
+	}
+	return data
+}
+
+type gexf struct {
+	XMLName xml.Name  `xml:"http://gexf.net/1.3 gexf"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Mode            string           `xml:"mode,attr"`
+	TimeFormat      string           `xml:"timeformat,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID     string         `xml:"id,attr"`
+	Label  string         `xml:"label,attr"`
+	Start  string         `xml:"start,attr,omitempty"`
+	Values []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID     string         `xml:"id,attr"`
+	Source string         `xml:"source,attr"`
+	Target string         `xml:"target,attr"`
+	Label  string         `xml:"label,attr,omitempty"`
+	Weight string         `xml:"weight,attr,omitempty"`
+	Values []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// WriteGEXF writes the graph as GEXF 1.3. Nodes start at the time they
+// happened or were created so that Gephi can play the graph on its timeline;
+// edges are weighted by their confidence.
+func WriteGEXF(w io.Writer, g *graph.Graph) error {
+	doc := gexf{Version: "1.3", Graph: gexfGraph{
+		DefaultEdgeType: "directed",
+		Mode:            "dynamic",
+		TimeFormat:      "datetime",
+	}}
+
+	nodeKeys := withFixedKeys(g.NodeAttributes(), graph.AttributeKey{Name: "collection", Type: graph.TypeString})
+	edgeKeys := withFixedKeys(g.EdgeAttributes(), graph.AttributeKey{Name: "confidence", Type: graph.TypeInt})
+	doc.Graph.Attributes = []gexfAttributes{
+		{Class: "node", Attributes: gexfAttributeList(nodeKeys)},
+		{Class: "edge", Attributes: gexfAttributeList(edgeKeys)},
+	}
+
+	for _, node := range g.Nodes {
+		n := gexfNode{
+			ID:     node.ID,
+			Label:  node.Label,
+			Values: gexfValues(nodeKeys, withFixed(node.Attributes, map[string]interface{}{"collection": node.Collection})),
+		}
+		if t := node.Time(); t != 0 {
+			n.Start = time.Unix(t, 0).UTC().Format(time.RFC3339)
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, n)
+	}
+	for _, edge := range g.Edges {
+		e := gexfEdge{
+			ID:     edge.ID,
+			Source: edge.From,
+			Target: edge.To,
+			Label:  edge.Name,
+			Values: gexfValues(edgeKeys, withFixed(edge.Attributes, map[string]interface{}{"confidence": float64(edge.Confidence)})),
+		}
+		if edge.Confidence > 0 {
+			e.Weight = strconv.FormatFloat(float64(edge.Confidence)/100, 'f', -1, 64)
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, e)
+	}
+
+	return writeXML(w, doc)
+}
+
+func gexfAttributeList(keys []graph.AttributeKey) []gexfAttribute {
+	attributes := make([]gexfAttribute, len(keys))
+	for i, key := range keys {
+		attributes[i] = gexfAttribute{ID: strconv.Itoa(i), Title: key.Name, Type: key.Type}
+	}
+	return attributes
+}
+
+func gexfValues(keys []graph.AttributeKey, attributes map[string]interface{}) []gexfAttValue {
+	var values []gexfAttValue
+	for i, key := range keys {
+		if value, ok := attributes[key.Name]; ok {
+			values = append(values, gexfAttValue{For: strconv.Itoa(i), Value: formatAttribute(value, key.Type)})
+		}
+	}
+	return values
+}
+
+// withFixedKeys lists the fixed columns first, followed by the document
+// attributes whose names they do not take.
+func withFixedKeys(attributes []map[string]interface{}, fixed ...graph.AttributeKey) []graph.AttributeKey {
+	keys := fixed
+	for _, key := range graph.AttributeKeys(attributes) {
+		taken := false
+		for _, f := range fixed {
+			taken = taken || f.Name == key.Name
+		}
+		if !taken {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// withFixed returns the attributes with the fixed columns set, leaving the
+// document attributes untouched.
+func withFixed(attributes, fixed map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(attributes)+len(fixed))
+	for k, v := range attributes {
+		merged[k] = v
+	}
+	for k, v := range fixed {
+		merged[k] = v
+	}
+	return merged
+}
+
+func formatAttribute(value interface{}, attributeType string) string {
+	switch v := value.(type) {
+	case float64:
+		if attributeType == graph.TypeLong || attributeType == graph.TypeInt {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+func writeXML(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
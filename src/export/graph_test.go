@@ -0,0 +1,76 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/omnsight/geovision/src/graph"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+func testGraph() *graph.Graph {
+	return graph.New([]map[string]interface{}{
+		{"_id": "events/1", "title": "Strike", "happened_at": float64(1706745600), "location": map[string]interface{}{"latitude": 50.0, "longitude": 36.25}},
+		{"_id": "organizations/1", "name": "Unit", "type": "military"},
+	}, []*model.Relation{
+		{Id: "relations/1", From: "organizations/1", To: "events/1", Name: "claimed", Confidence: 75},
+	})
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGraphML(&buf, testGraph()); err != nil {
+		t.Fatalf("Failed to write GraphML: %v", err)
+	}
+
+	var doc graphML
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse GraphML: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 2 || len(doc.Graph.Edges) != 1 {
+		t.Fatalf("Expected 2 nodes and 1 edge, got %d and %d", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+
+	keys := make(map[string]graphMLKey)
+	for _, key := range doc.Keys {
+		keys[key.ID] = key
+	}
+	values := make(map[string]string)
+	for _, data := range doc.Graph.Nodes[0].Data {
+		values[keys[data.Key].Name] = data.Value
+	}
+	if values["latitude"] != "50" || values["collection"] != "events" || values["happened_at"] != "1706745600" {
+		t.Errorf("Unexpected event node attributes: %v", values)
+	}
+
+	edge := doc.Graph.Edges[0]
+	if edge.Source != "organizations/1" || edge.Target != "events/1" {
+		t.Errorf("Unexpected edge: %+v", edge)
+	}
+}
+
+func TestWriteGEXF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGEXF(&buf, testGraph()); err != nil {
+		t.Fatalf("Failed to write GEXF: %v", err)
+	}
+
+	var doc gexf
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse GEXF: %v", err)
+	}
+	nodes := doc.Graph.Nodes
+	if len(nodes) != 2 || nodes[0].Start != "2024-02-01T00:00:00Z" || nodes[1].Label != "Unit" {
+		t.Errorf("Unexpected nodes: %+v", nodes)
+	}
+	if len(doc.Graph.Edges) != 1 || doc.Graph.Edges[0].Weight != "0.75" {
+		t.Errorf("Expected one edge weighted 0.75, got %+v", doc.Graph.Edges)
+	}
+
+	// The organization type stays a document attribute next to the collection
+	if !strings.Contains(buf.String(), `title="type"`) || !strings.Contains(buf.String(), `title="collection"`) {
+		t.Error("Expected both collection and type attributes")
+	}
+}
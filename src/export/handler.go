@@ -0,0 +1,243 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// Export formats served next to the generated gateway routes.
+const (
+	kmlContentType = "application/vnd.google-earth.kml+xml"
+	kmzContentType = "application/vnd.google-earth.kmz"
+	csvContentType = "text/csv"
+	// Parquet has no registered media type yet, this is the proposed one
+	parquetContentType = "application/vnd.apache.parquet"
+	gzipContentType    = "application/gzip"
+	cotContentType     = "application/xml"
+)
+
+// styleByParam is the query parameter selecting the placemark style.
+const styleByParam = "style_by"
+
+// staleParam is the query parameter setting the CoT stale time in seconds.
+const staleParam = "stale"
+
+// Handler renders GetEvents results in file formats the JSON gateway cannot
+// produce. It calls the gRPC server like the gateway does, so exports pass
+// through the same interceptors as every other request.
+type Handler struct {
+	mux    *runtime.ServeMux
+	client geovision.GeoServiceClient
+}
+
+// RegisterHandlers adds the export routes to the gateway multiplexer.
+func RegisterHandlers(mux *runtime.ServeMux, client geovision.GeoServiceClient) error {
+	h := &Handler{mux: mux, client: client}
+
+	routes := map[string]runtime.HandlerFunc{
+		"/v1/events/export.kml": h.serveEvents(kmlContentType, "events.kml", WriteKML),
+		"/v1/events/export.kmz": h.serveEvents(kmzContentType, "events.kmz", WriteKMZ),
+		"/v1/events/export.cot": h.serveCot,
+		"/v1/events/export.csv": h.serveTable(geovision.ExportFormat_EXPORT_FORMAT_CSV, csvContentType, "events.csv"),
+		"/v1/events/export.parquet": h.serveTable(
+			geovision.ExportFormat_EXPORT_FORMAT_PARQUET, parquetContentType, "events.parquet",
+		),
+		"/v1/events/export.graphml": h.serveGraph(geovision.GraphFormat_GRAPH_FORMAT_GRAPHML, "events.graphml"),
+		"/v1/events/export.gexf":    h.serveGraph(geovision.GraphFormat_GRAPH_FORMAT_GEXF, "events.gexf"),
+	}
+	for path, handler := range routes {
+		if err := mux.HandlePath(http.MethodGet, path, handler); err != nil {
+			return fmt.Errorf("failed to register %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// getEvents runs GetEvents with the filters from the query string, skipping
+// the given export-only parameters.
+func (h *Handler) getEvents(w http.ResponseWriter, r *http.Request, exportParams ...string) (*geovision.GetEventsResponse, bool) {
+	_, outbound := runtime.MarshalerForRequest(h.mux, r)
+
+	ctx, err := runtime.AnnotateContext(r.Context(), h.mux, r, geovision.GeoService_GetEvents_FullMethodName)
+	if err != nil {
+		runtime.HTTPError(r.Context(), h.mux, outbound, w, r, err)
+		return nil, false
+	}
+
+	var req geovision.GetEventsRequest
+	skip := make([][]string, 0, len(exportParams))
+	for _, param := range exportParams {
+		skip = append(skip, []string{param})
+	}
+	if err := runtime.PopulateQueryParameters(&req, r.URL.Query(), utilities.NewDoubleArray(skip)); err != nil {
+		runtime.HTTPError(ctx, h.mux, outbound, w, r, err)
+		return nil, false
+	}
+
+	resp, err := h.client.GetEvents(ctx, &req)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outbound, w, r, err)
+		return nil, false
+	}
+	return resp, true
+}
+
+func (h *Handler) serveEvents(contentType, filename string, write func(io.Writer, *geovision.GetEventsResponse, StyleBy) error) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		resp, ok := h.getEvents(w, r, styleByParam)
+		if !ok {
+			return
+		}
+
+		by := StyleBy(r.URL.Query().Get(styleByParam))
+		if by != StyleBySensitivity {
+			by = StyleByTag
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		if err := write(w, resp, by); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error": err,
+				"path":  r.URL.Path,
+			}).Error("failed to write export")
+		}
+	}
+}
+
+func (h *Handler) serveCot(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	stale := DefaultCotStale
+	if value := r.URL.Query().Get(staleParam); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds <= 0 {
+			http.Error(w, "stale must be a positive number of seconds", http.StatusBadRequest)
+			return
+		}
+		stale = time.Duration(seconds) * time.Second
+	}
+
+	resp, ok := h.getEvents(w, r, staleParam)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", cotContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "events.cot"))
+	if err := WriteCot(w, resp, stale); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+			"path":  r.URL.Path,
+		}).Error("failed to write export")
+	}
+}
+
+// serveTable streams ExportEvents in the format given by the path. The first
+// chunk is received before any header is written so that validation errors
+// still reach the client as regular gateway errors.
+func (h *Handler) serveTable(format geovision.ExportFormat, contentType, filename string) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(h.mux, r)
+
+		ctx, err := runtime.AnnotateContext(r.Context(), h.mux, r, geovision.GeoService_ExportEvents_FullMethodName)
+		if err != nil {
+			runtime.HTTPError(r.Context(), h.mux, outbound, w, r, err)
+			return
+		}
+
+		req := geovision.ExportEventsRequest{}
+		if err := runtime.PopulateQueryParameters(&req, r.URL.Query(), utilities.NewDoubleArray([][]string{{"format"}})); err != nil {
+			runtime.HTTPError(ctx, h.mux, outbound, w, r, err)
+			return
+		}
+		req.Format = format
+
+		stream, err := h.client.ExportEvents(ctx, &req)
+		if err != nil {
+			runtime.HTTPError(ctx, h.mux, outbound, w, r, err)
+			return
+		}
+
+		chunk, err := stream.Recv()
+		if err != nil && !errors.Is(err, io.EOF) {
+			runtime.HTTPError(ctx, h.mux, outbound, w, r, err)
+			return
+		}
+
+		mediaType, name := contentType, filename
+		if req.GetGzip() {
+			mediaType, name = gzipContentType, filename+".gz"
+		}
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+
+		for chunk != nil {
+			if _, err := w.Write(chunk.GetData()); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"error": err,
+					"path":  r.URL.Path,
+				}).Error("failed to write export")
+				return
+			}
+			if flusher, ok := w.(http.Flusher); ok {
+				flusher.Flush()
+			}
+
+			chunk, err = stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				// Headers are gone, so the best we can do is cut the body short
+				logrus.WithFields(logrus.Fields{
+					"error": err,
+					"path":  r.URL.Path,
+				}).Error("export stream failed")
+				return
+			}
+		}
+	}
+}
+
+// serveGraph renders ExportGraph in the format given by the path.
+func (h *Handler) serveGraph(format geovision.GraphFormat, filename string) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(h.mux, r)
+
+		ctx, err := runtime.AnnotateContext(r.Context(), h.mux, r, geovision.GeoService_ExportGraph_FullMethodName)
+		if err != nil {
+			runtime.HTTPError(r.Context(), h.mux, outbound, w, r, err)
+			return
+		}
+
+		req := geovision.ExportGraphRequest{}
+		if err := runtime.PopulateQueryParameters(&req, r.URL.Query(), utilities.NewDoubleArray([][]string{{"format"}})); err != nil {
+			runtime.HTTPError(ctx, h.mux, outbound, w, r, err)
+			return
+		}
+		req.Format = format
+
+		body, err := h.client.ExportGraph(ctx, &req)
+		if err != nil {
+			runtime.HTTPError(ctx, h.mux, outbound, w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", body.GetContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		if _, err := w.Write(body.GetData()); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error": err,
+				"path":  r.URL.Path,
+			}).Error("failed to write export")
+		}
+	}
+}
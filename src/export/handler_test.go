@@ -0,0 +1,148 @@
+package export
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClient answers GetEvents, ExportEvents and ExportGraph with fixed
+// responses and records the requests.
+type fakeClient struct {
+	geovision.GeoServiceClient
+	req  *geovision.GetEventsRequest
+	resp *geovision.GetEventsResponse
+	err  error
+
+	exportReq    *geovision.ExportEventsRequest
+	exportChunks []string
+	exportErr    error
+
+	graphReq *geovision.ExportGraphRequest
+}
+
+// fakeStream replays chunks and then fails with err, or ends the stream.
+type fakeStream struct {
+	grpc.ServerStreamingClient[httpbody.HttpBody]
+	chunks []string
+	err    error
+}
+
+func (s *fakeStream) Recv() (*httpbody.HttpBody, error) {
+	if len(s.chunks) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return &httpbody.HttpBody{Data: []byte(chunk)}, nil
+}
+
+func (c *fakeClient) ExportEvents(ctx context.Context, req *geovision.ExportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error) {
+	c.exportReq = req
+	return &fakeStream{chunks: c.exportChunks, err: c.exportErr}, nil
+}
+
+func (c *fakeClient) ExportGraph(ctx context.Context, req *geovision.ExportGraphRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	c.graphReq = req
+	return &httpbody.HttpBody{ContentType: GEXFContentType, Data: []byte("<gexf/>")}, nil
+}
+
+func (c *fakeClient) GetEvents(ctx context.Context, req *geovision.GetEventsRequest, opts ...grpc.CallOption) (*geovision.GetEventsResponse, error) {
+	c.req = req
+	return c.resp, c.err
+}
+
+func TestHandler(t *testing.T) {
+	client := &fakeClient{resp: testResponse()}
+	mux := runtime.NewServeMux()
+	if err := RegisterHandlers(mux, client); err != nil {
+		t.Fatalf("Failed to register handlers: %v", err)
+	}
+
+	t.Run("KML", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/events/export.kml?start_time=1&end_time=2&bbox.min_latitude=40&style_by=sensitivity", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != kmlContentType {
+			t.Errorf("Unexpected content type: %s", ct)
+		}
+		if client.req.GetStartTime() != 1 || client.req.GetEndTime() != 2 || client.req.GetBbox().GetMinLatitude() != 40 {
+			t.Errorf("Expected filters to be forwarded, got %v", client.req)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		client.err = status.Errorf(codes.InvalidArgument, "both start time and end time are required")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/events/export.kmz", nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", rec.Code)
+		}
+	})
+	t.Run("GEXF", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/events/export.gexf?start_time=1&end_time=2&depth=2&format=GRAPH_FORMAT_GRAPHML", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != GEXFContentType {
+			t.Errorf("Unexpected content type: %s", ct)
+		}
+		if client.graphReq.GetFormat() != geovision.GraphFormat_GRAPH_FORMAT_GEXF || client.graphReq.GetDepth() != 2 {
+			t.Errorf("Expected the path to select the format, got %v", client.graphReq)
+		}
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		client.exportChunks = []string{"id,title\n", "events/1,Strike\n"}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/events/export.csv?start_time=1&end_time=2&columns=id&columns=title&gzip=true", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != gzipContentType {
+			t.Errorf("Unexpected content type: %s", ct)
+		}
+		if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="events.csv.gz"` {
+			t.Errorf("Unexpected content disposition: %s", cd)
+		}
+		if rec.Body.String() != "id,title\nevents/1,Strike\n" {
+			t.Errorf("Unexpected body: %q", rec.Body.String())
+		}
+		if client.exportReq.GetFormat() != geovision.ExportFormat_EXPORT_FORMAT_CSV || len(client.exportReq.GetColumns()) != 2 || !client.exportReq.GetGzip() {
+			t.Errorf("Expected export options to be forwarded, got %v", client.exportReq)
+		}
+	})
+
+	t.Run("Parquet Error", func(t *testing.T) {
+		client.exportChunks = nil
+		client.exportErr = status.Errorf(codes.InvalidArgument, "unknown column")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/events/export.parquet?columns=nope", nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", rec.Code)
+		}
+		if client.exportReq.GetFormat() != geovision.ExportFormat_EXPORT_FORMAT_PARQUET {
+			t.Errorf("Expected Parquet format, got %v", client.exportReq.GetFormat())
+		}
+	})
+}
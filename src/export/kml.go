@@ -0,0 +1,333 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// StyleBy selects which event attribute drives placemark styling.
+type StyleBy string
+
+const (
+	StyleByTag         StyleBy = "tag"
+	StyleBySensitivity StyleBy = "sensitivity"
+)
+
+// defaultIcon is the Google Earth icon tinted by the style color in plain KML.
+const defaultIcon = "https://maps.google.com/mapfiles/kml/shapes/placemark_circle.png"
+
+// sensitivityColors maps each sensitivity to a color, from green to red.
+var sensitivityColors = map[model.Sensitivity]color.RGBA{
+	model.Sensitivity_SENSITIVITY_PUBLIC_UNSPECIFIED: {R: 0x2e, G: 0xa0, B: 0x43, A: 0xff},
+	model.Sensitivity_SENSITIVITY_PRIVILEGED:         {R: 0x1f, G: 0x6f, B: 0xeb, A: 0xff},
+	model.Sensitivity_SENSITIVITY_COMMERCIAL:         {R: 0xd2, G: 0x99, B: 0x22, A: 0xff},
+	model.Sensitivity_SENSITIVITY_CONFIDENTIAL:       {R: 0xcf, G: 0x22, B: 0x2e, A: 0xff},
+}
+
+// tagPalette is cycled through for tag styles.
+var tagPalette = []color.RGBA{
+	{R: 0xe6, G: 0x19, B: 0x4b, A: 0xff},
+	{R: 0x3c, G: 0xb4, B: 0x4b, A: 0xff},
+	{R: 0x43, G: 0x63, B: 0xd8, A: 0xff},
+	{R: 0xf5, G: 0x82, B: 0x31, A: 0xff},
+	{R: 0x91, G: 0x1e, B: 0xb4, A: 0xff},
+	{R: 0x42, G: 0xd4, B: 0xf4, A: 0xff},
+	{R: 0xf0, G: 0x32, B: 0xe6, A: 0xff},
+	{R: 0x80, G: 0x80, B: 0x00, A: 0xff},
+}
+
+// untagged is the color of events without tags.
+var untagged = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+
+type kmlDocument struct {
+	XMLName  xml.Name `xml:"kml"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Document kmlBody  `xml:"Document"`
+}
+
+type kmlBody struct {
+	Name       string         `xml:"name"`
+	Styles     []kmlStyle     `xml:"Style"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlStyle struct {
+	ID        string       `xml:"id,attr"`
+	IconStyle kmlIconStyle `xml:"IconStyle"`
+	LineStyle kmlLineStyle `xml:"LineStyle"`
+}
+
+type kmlIconStyle struct {
+	Color string  `xml:"color"`
+	Icon  kmlIcon `xml:"Icon"`
+}
+
+type kmlIcon struct {
+	Href string `xml:"href"`
+}
+
+type kmlLineStyle struct {
+	Color string `xml:"color"`
+	Width int    `xml:"width"`
+}
+
+type kmlPlacemark struct {
+	ID           string         `xml:"id,attr,omitempty"`
+	Name         string         `xml:"name"`
+	Description  string         `xml:"description,omitempty"`
+	TimeStamp    *kmlTimeStamp  `xml:"TimeStamp,omitempty"`
+	StyleURL     string         `xml:"styleUrl,omitempty"`
+	ExtendedData *kmlExtended   `xml:"ExtendedData,omitempty"`
+	Point        *kmlPoint      `xml:"Point,omitempty"`
+	LineString   *kmlLineString `xml:"LineString,omitempty"`
+}
+
+type kmlTimeStamp struct {
+	When string `xml:"when"`
+}
+
+type kmlExtended struct {
+	Data []kmlData `xml:"Data"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	Tessellate  int    `xml:"tessellate"`
+	Coordinates string `xml:"coordinates"`
+}
+
+// style is a named color shared by placemarks.
+type style struct {
+	id    string
+	color color.RGBA
+}
+
+// styler assigns styles to events and remembers which ones were used.
+type styler struct {
+	by     StyleBy
+	styles map[string]style
+	order  []string
+}
+
+func newStyler(by StyleBy) *styler {
+	return &styler{by: by, styles: make(map[string]style)}
+}
+
+func (s *styler) styleFor(event *model.Event) string {
+	var id string
+	var c color.RGBA
+
+	if s.by == StyleBySensitivity {
+		sensitivity := event.GetSensitivity()
+		id = "sensitivity-" + strings.ToLower(strings.TrimPrefix(sensitivity.String(), "SENSITIVITY_"))
+		c = sensitivityColors[sensitivity]
+	} else if len(event.GetTags()) == 0 {
+		id, c = "untagged", untagged
+	} else {
+		tag := event.GetTags()[0]
+		h := fnv.New32a()
+		h.Write([]byte(tag))
+		id = "tag-" + xmlID(tag)
+		c = tagPalette[h.Sum32()%uint32(len(tagPalette))]
+	}
+
+	if _, ok := s.styles[id]; !ok {
+		s.styles[id] = style{id: id, color: c}
+		s.order = append(s.order, id)
+	}
+	return id
+}
+
+// relationStyle is used for all relation lines.
+var relationStyle = style{id: "relation", color: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xb0}}
+
+// xmlID turns an arbitrary string into a valid XML ID fragment.
+func xmlID(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// kmlColor encodes a color in KML's aabbggrr order.
+func kmlColor(c color.RGBA) string {
+	return fmt.Sprintf("%02x%02x%02x%02x", c.A, c.B, c.G, c.R)
+}
+
+func coordinates(points ...geo.Point) string {
+	parts := make([]string, 0, len(points))
+	for _, p := range points {
+		parts = append(parts, fmt.Sprintf("%g,%g,0", p.Longitude, p.Latitude))
+	}
+	return strings.Join(parts, " ")
+}
+
+// iconPath is the location of a style's icon inside a KMZ archive.
+func iconPath(id string) string {
+	return "icons/" + id + ".png"
+}
+
+// buildKML converts events and relations into a KML document. When bundled
+// is set, icons point at files inside a KMZ archive.
+func buildKML(resp *geovision.GetEventsResponse, by StyleBy, bundled bool) (*kmlDocument, *styler) {
+	styles := newStyler(by)
+	body := kmlBody{Name: "Geovision events"}
+	located := make(map[string]geo.Point)
+
+	for _, event := range resp.GetEvents() {
+		p, ok := geo.EventPoint(event)
+		if !ok {
+			continue
+		}
+		located[event.GetId()] = p
+
+		placemark := kmlPlacemark{
+			ID:          xmlID(event.GetId()),
+			Name:        event.GetTitle(),
+			Description: event.GetDescription(),
+			StyleURL:    "#" + styles.styleFor(event),
+			Point:       &kmlPoint{Coordinates: coordinates(p)},
+			ExtendedData: &kmlExtended{Data: []kmlData{
+				{Name: "id", Value: event.GetId()},
+				{Name: "sensitivity", Value: event.GetSensitivity().String()},
+				{Name: "tags", Value: strings.Join(event.GetTags(), ",")},
+			}},
+		}
+		if event.GetHappenedAt() != 0 {
+			placemark.TimeStamp = &kmlTimeStamp{When: time.Unix(event.GetHappenedAt(), 0).UTC().Format(time.RFC3339)}
+		}
+		body.Placemarks = append(body.Placemarks, placemark)
+	}
+
+	for _, relation := range resp.GetRelations() {
+		from, okFrom := located[relation.GetFrom()]
+		to, okTo := located[relation.GetTo()]
+		if !okFrom || !okTo {
+			continue
+		}
+		body.Placemarks = append(body.Placemarks, kmlPlacemark{
+			ID:         xmlID(relation.GetId()),
+			Name:       relation.GetName(),
+			StyleURL:   "#" + relationStyle.id,
+			LineString: &kmlLineString{Tessellate: 1, Coordinates: coordinates(from, to)},
+			ExtendedData: &kmlExtended{Data: []kmlData{
+				{Name: "id", Value: relation.GetId()},
+				{Name: "confidence", Value: fmt.Sprint(relation.GetConfidence())},
+			}},
+		})
+	}
+
+	for _, id := range styles.order {
+		s := styles.styles[id]
+		// Bundled icons are already colored, so they must not be tinted again
+		iconStyle := kmlIconStyle{Color: kmlColor(s.color), Icon: kmlIcon{Href: defaultIcon}}
+		if bundled {
+			iconStyle = kmlIconStyle{Color: "ffffffff", Icon: kmlIcon{Href: iconPath(id)}}
+		}
+		body.Styles = append(body.Styles, kmlStyle{
+			ID:        id,
+			IconStyle: iconStyle,
+			LineStyle: kmlLineStyle{Color: kmlColor(s.color), Width: 2},
+		})
+	}
+	body.Styles = append(body.Styles, kmlStyle{
+		ID:        relationStyle.id,
+		IconStyle: kmlIconStyle{Color: kmlColor(relationStyle.color), Icon: kmlIcon{Href: defaultIcon}},
+		LineStyle: kmlLineStyle{Color: kmlColor(relationStyle.color), Width: 2},
+	})
+
+	return &kmlDocument{Xmlns: "http://www.opengis.net/kml/2.2", Document: body}, styles
+}
+
+func encodeKML(w io.Writer, doc *kmlDocument) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// WriteKML renders events as Placemarks and relations between located events
+// as LineStrings.
+func WriteKML(w io.Writer, resp *geovision.GetEventsResponse, by StyleBy) error {
+	doc, _ := buildKML(resp, by, false)
+	return encodeKML(w, doc)
+}
+
+// WriteKMZ writes a KMZ archive with the KML document and one colored icon
+// per style.
+func WriteKMZ(w io.Writer, resp *geovision.GetEventsResponse, by StyleBy) error {
+	doc, styles := buildKML(resp, by, true)
+
+	archive := zip.NewWriter(w)
+	file, err := archive.Create("doc.kml")
+	if err != nil {
+		return err
+	}
+	if err := encodeKML(file, doc); err != nil {
+		return err
+	}
+
+	for _, id := range styles.order {
+		icon, err := archive.Create(iconPath(id))
+		if err != nil {
+			return err
+		}
+		if err := writeIcon(icon, styles.styles[id].color); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// writeIcon draws a filled circle with a white outline as a PNG.
+func writeIcon(w io.Writer, c color.RGBA) error {
+	const size = 32
+	const radius = size/2 - 1
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := x-size/2, y-size/2
+			d := dx*dx + dy*dy
+			switch {
+			case d <= (radius-3)*(radius-3):
+				img.Set(x, y, c)
+			case d <= radius*radius:
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
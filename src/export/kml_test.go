@@ -0,0 +1,92 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+func testResponse() *geovision.GetEventsResponse {
+	return &geovision.GetEventsResponse{
+		Events: []*model.Event{
+			{
+				Id:          "events/1",
+				Title:       "Strike <A>",
+				HappenedAt:  1700000000,
+				Tags:        []string{"artillery"},
+				Sensitivity: model.Sensitivity_SENSITIVITY_CONFIDENTIAL,
+				Location:    &model.LocationData{Latitude: 50, Longitude: 36.25},
+			},
+			{
+				Id:         "events/2",
+				Title:      "Convoy",
+				HappenedAt: 1700003600,
+				Location:   &model.LocationData{Latitude: 49.5, Longitude: 36},
+			},
+			{
+				Id:    "events/3",
+				Title: "Unlocated",
+			},
+		},
+		Relations: []*model.Relation{
+			{Id: "relations/1", From: "events/1", To: "events/2", Name: "followed_by", Confidence: 80},
+			{Id: "relations/2", From: "events/1", To: "events/3", Name: "related_to"},
+		},
+	}
+}
+
+func TestWriteKML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteKML(&buf, testResponse(), StyleBySensitivity); err != nil {
+		t.Fatalf("Failed to write KML: %v", err)
+	}
+
+	var doc kmlDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse KML: %v", err)
+	}
+
+	// Two located events and the one relation between them
+	placemarks := doc.Document.Placemarks
+	if len(placemarks) != 3 {
+		t.Fatalf("Expected 3 placemarks, got %d", len(placemarks))
+	}
+	if placemarks[0].Name != "Strike <A>" || placemarks[0].Point.Coordinates != "36.25,50,0" {
+		t.Errorf("Unexpected first placemark: %+v", placemarks[0])
+	}
+	if placemarks[0].TimeStamp == nil || placemarks[0].TimeStamp.When != "2023-11-14T22:13:20Z" {
+		t.Errorf("Unexpected timestamp: %+v", placemarks[0].TimeStamp)
+	}
+	if placemarks[0].StyleURL != "#sensitivity-confidential" {
+		t.Errorf("Unexpected style: %s", placemarks[0].StyleURL)
+	}
+	if placemarks[2].LineString == nil || placemarks[2].LineString.Coordinates != "36.25,50,0 36,49.5,0" {
+		t.Errorf("Unexpected relation placemark: %+v", placemarks[2])
+	}
+}
+
+func TestWriteKMZ(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteKMZ(&buf, testResponse(), StyleByTag); err != nil {
+		t.Fatalf("Failed to write KMZ: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to open KMZ: %v", err)
+	}
+
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	want := "doc.kml,icons/tag-artillery.png,icons/untagged.png"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("Expected archive files %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,386 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// StixContentType is the media type of a STIX 2.1 bundle.
+const StixContentType = "application/stix+json;version=2.1"
+
+// stixNamespace seeds the UUIDv5 identifiers of domain objects so the same
+// record always maps to the same STIX id across exports.
+var stixNamespace = uuid.MustParse("6f1f5b6e-2f0c-4b8e-9a53-3c1d2e7f0a41")
+
+// scoNamespace is the namespace the STIX specification mandates for cyber
+// observable identifiers.
+var scoNamespace = uuid.MustParse("00abedb4-aa42-466c-9c01-fed23315a9b7")
+
+// tlpMarkings maps sensitivities to the predefined STIX TLP markings.
+var tlpMarkings = map[model.Sensitivity]string{
+	model.Sensitivity_SENSITIVITY_PUBLIC_UNSPECIFIED: "marking-definition--613f2e26-407d-48c7-9eca-b8e91df99dc9",
+	model.Sensitivity_SENSITIVITY_PRIVILEGED:         "marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da",
+	model.Sensitivity_SENSITIVITY_COMMERCIAL:         "marking-definition--f88d31f6-486f-44da-b317-01333bde0b82",
+	model.Sensitivity_SENSITIVITY_CONFIDENTIAL:       "marking-definition--5e57c739-391a-4eb3-b6be-7d15ca92d5ed",
+}
+
+// stixObject is a STIX object; maps marshal with sorted keys, which keeps the
+// output stable.
+type stixObject map[string]interface{}
+
+// stixBuilder collects STIX objects keyed by id.
+type stixBuilder struct {
+	producer string
+	objects  map[string]stixObject
+}
+
+// stixID derives the id of a domain or relationship object from a stable key.
+func stixID(stixType, key string) string {
+	return stixType + "--" + uuid.NewSHA1(stixNamespace, []byte(stixType+":"+key)).String()
+}
+
+// urlID derives the id of a url observable from its value as the
+// specification requires.
+func urlID(value string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(map[string]string{"value": value})
+	return "url--" + uuid.NewSHA1(scoNamespace, bytes.TrimSpace(buf.Bytes())).String()
+}
+
+// stixTime formats a Unix timestamp in the STIX timestamp format.
+func stixTime(seconds int64) string {
+	return time.Unix(seconds, 0).UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// firstNonEmpty returns the first non-empty string, or an empty one.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// firstNonZero returns the first non-zero timestamp, or zero.
+func firstNonZero(timestamps ...int64) int64 {
+	for _, t := range timestamps {
+		if t != 0 {
+			return t
+		}
+	}
+	return 0
+}
+
+var nonVocabChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// relationshipType turns a relation name into a valid STIX relationship type.
+func vocabTerm(name string) string {
+	t := strings.Trim(nonVocabChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if t == "" {
+		return "related-to"
+	}
+	return t
+}
+
+func newStixBuilder() *stixBuilder {
+	b := &stixBuilder{objects: make(map[string]stixObject)}
+	b.producer = stixID("identity", "geovision")
+	b.objects[b.producer] = stixObject{
+		"type":           "identity",
+		"spec_version":   "2.1",
+		"id":             b.producer,
+		"created":        stixTime(0),
+		"modified":       stixTime(0),
+		"name":           "Geovision",
+		"identity_class": "system",
+	}
+	return b
+}
+
+// domainObject creates the common properties of a domain object.
+func (b *stixBuilder) domainObject(stixType, key string, sensitivity model.Sensitivity, created, modified int64) stixObject {
+	id := stixID(stixType, key)
+	obj := stixObject{
+		"type":                stixType,
+		"spec_version":        "2.1",
+		"id":                  id,
+		"created":             stixTime(created),
+		"modified":            stixTime(firstNonZero(modified, created)),
+		"created_by_ref":      b.producer,
+		"object_marking_refs": []string{tlpMarkings[sensitivity]},
+	}
+	b.objects[id] = obj
+	return obj
+}
+
+func withLabels(obj stixObject, tags []string) {
+	if len(tags) > 0 {
+		obj["labels"] = tags
+	}
+}
+
+// addEvent adds an event as a grouping of the objects that describe it,
+// starting with its position as a location.
+func (b *stixBuilder) addEvent(event *model.Event) stixObject {
+	happened := event.GetHappenedAt()
+	grouping := b.domainObject("grouping", event.GetId(), event.GetSensitivity(), firstNonZero(happened, event.GetUpdatedAt()), event.GetUpdatedAt())
+	grouping["name"] = firstNonEmpty(event.GetTitle(), event.GetId())
+	grouping["context"] = "unspecified"
+	grouping["object_refs"] = []string{}
+	if description := event.GetDescription(); description != "" {
+		grouping["description"] = description
+	}
+	withLabels(grouping, event.GetTags())
+
+	p, ok := geo.EventPoint(event)
+	if !ok {
+		return grouping
+	}
+
+	location := b.domainObject("location", event.GetId(), event.GetSensitivity(), firstNonZero(happened, event.GetUpdatedAt()), event.GetUpdatedAt())
+	location["name"] = event.GetTitle()
+	location["latitude"] = p.Latitude
+	location["longitude"] = p.Longitude
+	if description := event.GetDescription(); description != "" {
+		location["description"] = description
+	}
+	l := event.GetLocation()
+	for property, value := range map[string]string{
+		"country":             l.GetCountryCode(),
+		"administrative_area": l.GetAdministrativeArea(),
+		"city":                l.GetLocality(),
+		"street_address":      l.GetAddress(),
+	} {
+		if value != "" {
+			location[property] = value
+		}
+	}
+	if l.GetPostalCode() != 0 {
+		location["postal_code"] = strconv.Itoa(int(l.GetPostalCode()))
+	}
+
+	b.reference(grouping, location["id"].(string))
+	return grouping
+}
+
+// addObservation adds a cyber observable seen in an event to the event's
+// observed-data, creating it on first use, since observed-data may only
+// reference observables.
+func (b *stixBuilder) addObservation(event *model.Event, grouping, observable stixObject) stixObject {
+	id := stixID("observed-data", event.GetId())
+	observed, ok := b.objects[id]
+	if !ok {
+		happened := event.GetHappenedAt()
+		observed = b.domainObject("observed-data", event.GetId(), event.GetSensitivity(), firstNonZero(happened, event.GetUpdatedAt()), event.GetUpdatedAt())
+		observed["first_observed"] = stixTime(happened)
+		observed["last_observed"] = stixTime(happened)
+		observed["number_observed"] = 1
+		observed["object_refs"] = []string{}
+		b.reference(grouping, id)
+	}
+	b.reference(observed, observable["id"].(string))
+	return observed
+}
+
+// reference adds an id to the object_refs of a grouping or observed-data.
+func (b *stixBuilder) reference(obj stixObject, id string) {
+	refs := obj["object_refs"].([]string)
+	for _, ref := range refs {
+		if ref == id {
+			return
+		}
+	}
+	obj["object_refs"] = append(refs, id)
+}
+
+// addEntity adds a related entity and returns the object relations should
+// point at, or nil if the entity has no STIX counterpart.
+func (b *stixBuilder) addEntity(entity *model.RelatedEntity) stixObject {
+	switch {
+	case entity.GetPerson() != nil:
+		person := entity.GetPerson()
+		obj := b.domainObject("identity", person.GetId(), person.GetSensitivity(), person.GetUpdatedAt(), person.GetUpdatedAt())
+		obj["name"] = person.GetName()
+		obj["identity_class"] = "individual"
+		if person.GetRole() != "" {
+			obj["roles"] = []string{person.GetRole()}
+		}
+		withLabels(obj, person.GetTags())
+		return obj
+
+	case entity.GetOrganization() != nil:
+		org := entity.GetOrganization()
+		obj := b.domainObject("identity", org.GetId(), org.GetSensitivity(), firstNonZero(org.GetDiscoveredAt(), org.GetFoundedAt()), org.GetLastVisited())
+		obj["name"] = org.GetName()
+		obj["identity_class"] = "organization"
+		if org.GetType() != "" {
+			obj["sectors"] = []string{vocabTerm(org.GetType())}
+		}
+		withLabels(obj, org.GetTags())
+		return obj
+
+	case entity.GetSource() != nil:
+		source := entity.GetSource()
+		obj := b.domainObject("identity", source.GetId(), source.GetSensitivity(), source.GetCreatedAt(), source.GetUpdatedAt())
+		obj["name"] = source.GetName()
+		obj["identity_class"] = "organization"
+		if source.GetUrl() != "" {
+			obj["external_references"] = []stixObject{{"source_name": source.GetName(), "url": source.GetUrl()}}
+		}
+		withLabels(obj, source.GetTags())
+		return obj
+
+	case entity.GetWebsite() != nil:
+		website := entity.GetWebsite()
+		if website.GetUrl() == "" {
+			return nil
+		}
+		id := urlID(website.GetUrl())
+		obj := stixObject{
+			"type":         "url",
+			"spec_version": "2.1",
+			"id":           id,
+			"value":        website.GetUrl(),
+		}
+		b.objects[id] = obj
+		return obj
+	}
+	return nil
+}
+
+// relationship adds a relationship object between two objects.
+func (b *stixBuilder) relationship(key, relationType string, source, target stixObject, sensitivity model.Sensitivity, confidence int32, created, modified int64) stixObject {
+	obj := b.domainObject("relationship", key, sensitivity, created, modified)
+	obj["relationship_type"] = relationType
+	obj["source_ref"] = source["id"]
+	obj["target_ref"] = target["id"]
+	if confidence > 0 {
+		obj["confidence"] = min(confidence, 100)
+	}
+	return obj
+}
+
+// addRelation adds a relation edge between two objects already in the bundle.
+func (b *stixBuilder) addRelation(relation *model.Relation, source, target stixObject) stixObject {
+	return b.relationship(
+		relation.GetId(), vocabTerm(relation.GetName()), source, target,
+		relation.GetSensitivity(), relation.GetConfidence(), relation.GetCreatedAt(), relation.GetUpdatedAt(),
+	)
+}
+
+// addSighting records that an identity was seen in an event, where the
+// event took place.
+func (b *stixBuilder) addSighting(relation *model.Relation, identity stixObject, event *model.Event) stixObject {
+	sighting := b.domainObject("sighting", relation.GetId(), event.GetSensitivity(), firstNonZero(event.GetHappenedAt(), event.GetUpdatedAt()), event.GetUpdatedAt())
+	sighting["sighting_of_ref"] = identity["id"]
+	sighting["first_seen"] = stixTime(event.GetHappenedAt())
+	sighting["last_seen"] = stixTime(event.GetHappenedAt())
+	sighting["count"] = 1
+	if _, ok := geo.EventPoint(event); ok {
+		sighting["where_sighted_refs"] = []string{stixID("location", event.GetId())}
+	}
+	return sighting
+}
+
+// BuildStixBundle converts events, the relations between them and their
+// related entities into a STIX 2.1 bundle. Events become groupings of their
+// location and related objects, persons, organizations and sources become
+// identities, websites become url observables in the event's observed-data
+// and relation edges become relationships. Every id is
+// derived from the underlying record, so exporting the same data twice yields
+// the same bundle.
+func BuildStixBundle(resp *geovision.GetEventsResponse, related []*model.RelatedEntity) ([]byte, error) {
+	b := newStixBuilder()
+
+	events := make(map[string]*model.Event, len(resp.GetEvents()))
+	groupings := make(map[string]stixObject, len(resp.GetEvents()))
+	for _, event := range resp.GetEvents() {
+		events[event.GetId()] = event
+		groupings[event.GetId()] = b.addEvent(event)
+	}
+
+	for _, relation := range resp.GetRelations() {
+		from, okFrom := groupings[relation.GetFrom()]
+		to, okTo := groupings[relation.GetTo()]
+		if !okFrom || !okTo {
+			continue
+		}
+		b.addRelation(relation, from, to)
+		b.reference(from, to["id"].(string))
+		b.reference(to, from["id"].(string))
+	}
+
+	// Sightings of each event, which point at its observed-data once all
+	// observables are known
+	sightings := make(map[string][]stixObject)
+	for _, entity := range related {
+		relation := entity.GetRelation()
+		from, ok := groupings[relation.GetFrom()]
+		if !ok {
+			continue
+		}
+		target := b.addEntity(entity)
+		if target == nil {
+			continue
+		}
+
+		b.addRelation(relation, from, target)
+		b.reference(from, target["id"].(string))
+		if target["type"] == "url" {
+			b.addObservation(events[relation.GetFrom()], from, target)
+		}
+		if entity.GetPerson() != nil || entity.GetOrganization() != nil {
+			sightings[relation.GetFrom()] = append(sightings[relation.GetFrom()], b.addSighting(relation, target, events[relation.GetFrom()]))
+		}
+	}
+
+	for eventID, eventSightings := range sightings {
+		observed := stixID("observed-data", eventID)
+		if _, ok := b.objects[observed]; !ok {
+			continue
+		}
+		for _, sighting := range eventSightings {
+			sighting["observed_data_refs"] = []string{observed}
+		}
+	}
+
+	// A grouping must reference at least one object, so events that are
+	// neither located nor related carry nothing STIX can express
+	for id, obj := range groupings {
+		if len(obj["object_refs"].([]string)) == 0 {
+			delete(b.objects, obj["id"].(string))
+			delete(groupings, id)
+		}
+	}
+
+	ids := make([]string, 0, len(b.objects))
+	for id := range b.objects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	objects := make([]stixObject, 0, len(ids))
+	for _, id := range ids {
+		objects = append(objects, b.objects[id])
+	}
+
+	bundle := stixObject{
+		"type":    "bundle",
+		"id":      stixID("bundle", strings.Join(ids, ",")),
+		"objects": objects,
+	}
+	return json.Marshal(bundle)
+}
@@ -0,0 +1,175 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func testRelated(t *testing.T) []*model.RelatedEntity {
+	var related []*model.RelatedEntity
+	for _, raw := range []string{
+		`{"relation": {"id": "relations/3", "from": "events/1", "to": "persons/1", "name": "Commanded By", "confidence": 70}, "person": {"id": "persons/1", "name": "John Doe", "role": "commander"}}`,
+		`{"relation": {"id": "relations/4", "from": "events/2", "to": "websites/1", "name": "reported_on"}, "website": {"id": "websites/1", "url": "https://example.com/report"}}`,
+	} {
+		var entity model.RelatedEntity
+		if err := protojson.Unmarshal([]byte(raw), &entity); err != nil {
+			t.Fatalf("Failed to decode related entity: %v", err)
+		}
+		related = append(related, &entity)
+	}
+	return related
+}
+
+func TestBuildStixBundle(t *testing.T) {
+	data, err := BuildStixBundle(testResponse(), testRelated(t))
+	if err != nil {
+		t.Fatalf("Failed to build bundle: %v", err)
+	}
+
+	var bundle struct {
+		Type    string                   `json:"type"`
+		Objects []map[string]interface{} `json:"objects"`
+	}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		t.Fatalf("Failed to parse bundle: %v", err)
+	}
+	if bundle.Type != "bundle" {
+		t.Errorf("Expected bundle, got %s", bundle.Type)
+	}
+
+	counts := make(map[string]int)
+	byID := make(map[string]map[string]interface{})
+	for _, obj := range bundle.Objects {
+		counts[obj["type"].(string)]++
+		byID[obj["id"].(string)] = obj
+	}
+
+	expected := map[string]int{
+		"grouping":      3,
+		"observed-data": 1, // only the event with a website
+		"location":      2,
+		"identity":      2, // producer and person
+		"url":           1,
+		"sighting":      1,
+		"relationship":  4, // 2 event to event, 2 event to entity
+	}
+	for stixType, count := range expected {
+		if counts[stixType] != count {
+			t.Errorf("Expected %d %s objects, got %d", count, stixType, counts[stixType])
+		}
+	}
+
+	t.Run("Relationships", func(t *testing.T) {
+		for _, obj := range bundle.Objects {
+			if obj["type"] != "relationship" {
+				continue
+			}
+			for _, ref := range []string{"source_ref", "target_ref"} {
+				if _, ok := byID[obj[ref].(string)]; !ok {
+					t.Errorf("Dangling %s %v", ref, obj[ref])
+				}
+			}
+			if obj["relationship_type"] == "commanded-by" && obj["confidence"] != float64(70) {
+				t.Errorf("Expected confidence 70, got %v", obj["confidence"])
+			}
+		}
+	})
+
+	t.Run("Object References", func(t *testing.T) {
+		observables := map[string]bool{"url": true, "domain-name": true}
+		for _, obj := range bundle.Objects {
+			if obj["type"] != "observed-data" && obj["type"] != "grouping" {
+				continue
+			}
+			refs, _ := obj["object_refs"].([]interface{})
+			if len(refs) == 0 {
+				t.Errorf("Expected %s to reference at least one object", obj["id"])
+			}
+			observable := false
+			for _, ref := range refs {
+				target, ok := byID[ref.(string)]
+				if !ok {
+					t.Errorf("Dangling object_ref %v in %s", ref, obj["id"])
+					continue
+				}
+				if target["type"] == "relationship" && target["source_ref"] == obj["id"] {
+					t.Errorf("Expected %s not to reference its own relationship %s", obj["id"], ref)
+				}
+				observable = observable || observables[target["type"].(string)]
+			}
+			if obj["type"] == "observed-data" && !observable {
+				t.Errorf("Expected observed-data %s to reference an observable", obj["id"])
+			}
+		}
+	})
+
+	t.Run("Sighting", func(t *testing.T) {
+		for _, obj := range bundle.Objects {
+			if obj["type"] != "sighting" {
+				continue
+			}
+			where, _ := obj["where_sighted_refs"].([]interface{})
+			if len(where) != 1 || byID[where[0].(string)]["type"] != "location" {
+				t.Errorf("Expected the sighting at the event location, got %v", obj["where_sighted_refs"])
+			}
+			if _, ok := obj["observed_data_refs"]; ok {
+				t.Errorf("Expected no observed-data for an event without observables, got %v", obj["observed_data_refs"])
+			}
+		}
+	})
+
+	t.Run("Postal Code", func(t *testing.T) {
+		resp := testResponse()
+		resp.Events[1].Location.PostalCode = 61000
+		data, err := BuildStixBundle(resp, testRelated(t))
+		if err != nil {
+			t.Fatalf("Failed to build bundle: %v", err)
+		}
+		var bundle struct {
+			Objects []map[string]interface{} `json:"objects"`
+		}
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			t.Fatalf("Failed to parse bundle: %v", err)
+		}
+		found := false
+		for _, obj := range bundle.Objects {
+			if code, ok := obj["postal_code"]; ok {
+				found = true
+				if code != "61000" {
+					t.Errorf("Expected the postal code as a string, got %#v", code)
+				}
+			}
+		}
+		if !found {
+			t.Error("Expected a location with a postal code")
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		again, err := BuildStixBundle(testResponse(), testRelated(t))
+		if err != nil {
+			t.Fatalf("Failed to build bundle: %v", err)
+		}
+		if !bytes.Equal(data, again) {
+			t.Error("Expected identical bundles for identical input")
+		}
+	})
+}
+
+func TestVocabTerm(t *testing.T) {
+	cases := map[string]string{
+		"followed_by":  "followed-by",
+		"Commanded By": "commanded-by",
+		"":             "related-to",
+		"--":           "related-to",
+	}
+	for name, expected := range cases {
+		if got := vocabTerm(name); got != expected {
+			t.Errorf("Expected %q for %q, got %q", expected, name, got)
+		}
+	}
+}
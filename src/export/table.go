@@ -0,0 +1,250 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/parquet-go/parquet-go"
+)
+
+// columnKind is the storage type of a column.
+type columnKind int
+
+const (
+	kindString columnKind = iota
+	kindInt
+	kindDouble
+)
+
+// Column is one flattened field of an event.
+type Column struct {
+	Name  string
+	kind  columnKind
+	value func(*model.Event) interface{}
+}
+
+// Columns lists every exportable column in default order. Location fields
+// are flattened and tags are joined with ";".
+var Columns = []Column{
+	{Name: "id", kind: kindString, value: func(e *model.Event) interface{} { return e.GetId() }},
+	{Name: "key", kind: kindString, value: func(e *model.Event) interface{} { return e.GetKey() }},
+	{Name: "title", kind: kindString, value: func(e *model.Event) interface{} { return e.GetTitle() }},
+	{Name: "description", kind: kindString, value: func(e *model.Event) interface{} { return e.GetDescription() }},
+	{Name: "happened_at", kind: kindInt, value: func(e *model.Event) interface{} { return e.GetHappenedAt() }},
+	{Name: "updated_at", kind: kindInt, value: func(e *model.Event) interface{} { return e.GetUpdatedAt() }},
+	{Name: "sensitivity", kind: kindString, value: func(e *model.Event) interface{} { return e.GetSensitivity().String() }},
+	{Name: "latitude", kind: kindDouble, value: func(e *model.Event) interface{} { return float64(e.GetLocation().GetLatitude()) }},
+	{Name: "longitude", kind: kindDouble, value: func(e *model.Event) interface{} { return float64(e.GetLocation().GetLongitude()) }},
+	{Name: "country_code", kind: kindString, value: func(e *model.Event) interface{} { return e.GetLocation().GetCountryCode() }},
+	{Name: "administrative_area", kind: kindString, value: func(e *model.Event) interface{} { return e.GetLocation().GetAdministrativeArea() }},
+	{Name: "sub_administrative_area", kind: kindString, value: func(e *model.Event) interface{} { return e.GetLocation().GetSubAdministrativeArea() }},
+	{Name: "locality", kind: kindString, value: func(e *model.Event) interface{} { return e.GetLocation().GetLocality() }},
+	{Name: "sub_locality", kind: kindString, value: func(e *model.Event) interface{} { return e.GetLocation().GetSubLocality() }},
+	{Name: "address", kind: kindString, value: func(e *model.Event) interface{} { return e.GetLocation().GetAddress() }},
+	{Name: "postal_code", kind: kindInt, value: func(e *model.Event) interface{} { return int64(e.GetLocation().GetPostalCode()) }},
+	{Name: "tags", kind: kindString, value: func(e *model.Event) interface{} { return strings.Join(e.GetTags(), ";") }},
+}
+
+// SelectColumns returns the named columns in the given order, or all columns
+// when no names are given.
+func SelectColumns(names []string) ([]Column, error) {
+	if len(names) == 0 {
+		return Columns, nil
+	}
+
+	byName := make(map[string]Column, len(Columns))
+	for _, c := range Columns {
+		byName[c.Name] = c
+	}
+
+	selected := make([]Column, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		seen[name] = true
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+// RowWriter writes events as rows of a table.
+type RowWriter interface {
+	Write(event *model.Event) error
+	Close() error
+}
+
+// csvWriter writes a header line followed by one line per event.
+type csvWriter struct {
+	w       *csv.Writer
+	columns []Column
+	record  []string
+	header  bool
+}
+
+// NewCSVWriter creates a RowWriter producing CSV.
+func NewCSVWriter(w io.Writer, columns []Column) RowWriter {
+	return &csvWriter{w: csv.NewWriter(w), columns: columns, record: make([]string, len(columns))}
+}
+
+func (c *csvWriter) writeHeader() error {
+	for i, column := range c.columns {
+		c.record[i] = column.Name
+	}
+	c.header = true
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Write(event *model.Event) error {
+	if !c.header {
+		if err := c.writeHeader(); err != nil {
+			return err
+		}
+	}
+	for i, column := range c.columns {
+		switch v := column.value(event).(type) {
+		case string:
+			c.record[i] = v
+		case int64:
+			c.record[i] = strconv.FormatInt(v, 10)
+		case float64:
+			c.record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Close() error {
+	if !c.header {
+		if err := c.writeHeader(); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// parquetRowGroupSize bounds how many rows are buffered before a row group
+// is written out, which keeps memory flat for large exports.
+const parquetRowGroupSize = 10000
+
+// parquetWriter writes events as rows of a Parquet file.
+type parquetWriter struct {
+	w       *parquet.Writer
+	columns []Column
+	// index maps a column to its leaf position in the schema
+	index []int
+	rows  []parquet.Row
+}
+
+// NewParquetWriter creates a RowWriter producing Parquet.
+func NewParquetWriter(w io.Writer, columns []Column) RowWriter {
+	group := parquet.Group{}
+	for _, c := range columns {
+		switch c.kind {
+		case kindInt:
+			group[c.Name] = parquet.Int(64)
+		case kindDouble:
+			group[c.Name] = parquet.Leaf(parquet.DoubleType)
+		default:
+			group[c.Name] = parquet.String()
+		}
+	}
+	schema := parquet.NewSchema("event", group)
+
+	// Parquet groups order their fields by name
+	positions := make(map[string]int, len(columns))
+	for i, field := range schema.Fields() {
+		positions[field.Name()] = i
+	}
+	index := make([]int, len(columns))
+	for i, c := range columns {
+		index[i] = positions[c.Name]
+	}
+
+	return &parquetWriter{
+		w:       parquet.NewWriter(w, schema, parquet.MaxRowsPerRowGroup(parquetRowGroupSize)),
+		columns: columns,
+		index:   index,
+	}
+}
+
+func (p *parquetWriter) Write(event *model.Event) error {
+	row := make(parquet.Row, len(p.columns))
+	for i, column := range p.columns {
+		row[p.index[i]] = parquet.ValueOf(column.value(event)).Level(0, 0, p.index[i])
+	}
+	p.rows = append(p.rows, row)
+
+	if len(p.rows) >= parquetRowGroupSize {
+		return p.flush()
+	}
+	return nil
+}
+
+func (p *parquetWriter) flush() error {
+	if len(p.rows) == 0 {
+		return nil
+	}
+	if _, err := p.w.WriteRows(p.rows); err != nil {
+		return err
+	}
+	p.rows = p.rows[:0]
+	return p.w.Flush()
+}
+
+func (p *parquetWriter) Close() error {
+	if err := p.flush(); err != nil {
+		return err
+	}
+	return p.w.Close()
+}
+
+// ChunkWriter buffers writes and hands them on in chunks of a fixed size,
+// which suits streaming a file as a sequence of messages.
+type ChunkWriter struct {
+	send func([]byte) error
+	buf  []byte
+}
+
+// NewChunkWriter creates a ChunkWriter that calls send with up to size bytes.
+func NewChunkWriter(size int, send func([]byte) error) *ChunkWriter {
+	return &ChunkWriter{send: send, buf: make([]byte, 0, size)}
+}
+
+func (c *ChunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := copy(c.buf[len(c.buf):cap(c.buf)], p)
+		c.buf = c.buf[:len(c.buf)+n]
+		p = p[n:]
+		written += n
+
+		if len(c.buf) == cap(c.buf) {
+			if err := c.Flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Flush sends any buffered bytes.
+func (c *ChunkWriter) Flush() error {
+	if len(c.buf) == 0 {
+		return nil
+	}
+	chunk := make([]byte, len(c.buf))
+	copy(chunk, c.buf)
+	c.buf = c.buf[:0]
+	return c.send(chunk)
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestSelectColumns(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		columns, err := SelectColumns(nil)
+		if err != nil {
+			t.Fatalf("Failed to select columns: %v", err)
+		}
+		if len(columns) != len(Columns) {
+			t.Errorf("Expected all %d columns, got %d", len(Columns), len(columns))
+		}
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		columns, err := SelectColumns([]string{"title", "id"})
+		if err != nil {
+			t.Fatalf("Failed to select columns: %v", err)
+		}
+		if len(columns) != 2 || columns[0].Name != "title" || columns[1].Name != "id" {
+			t.Errorf("Expected requested order, got %v", columns)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := SelectColumns([]string{"nope"}); err == nil {
+			t.Error("Expected error for unknown column")
+		}
+		if _, err := SelectColumns([]string{"id", "id"}); err == nil {
+			t.Error("Expected error for duplicate column")
+		}
+	})
+}
+
+func TestCSVWriter(t *testing.T) {
+	columns, _ := SelectColumns([]string{"id", "title", "latitude", "happened_at", "tags"})
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, columns)
+	for _, event := range testResponse().GetEvents() {
+		if err := w.Write(event); err != nil {
+			t.Fatalf("Failed to write event: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("Expected header and 3 rows, got %d", len(records))
+	}
+	if records[0][0] != "id" || records[0][4] != "tags" {
+		t.Errorf("Unexpected header: %v", records[0])
+	}
+	if records[1][1] != "Strike <A>" || records[1][2] != "50" || records[1][3] != "1700000000" || records[1][4] != "artillery" {
+		t.Errorf("Unexpected row: %v", records[1])
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewCSVWriter(&buf, columns)
+		if err := w.Close(); err != nil {
+			t.Fatalf("Failed to close writer: %v", err)
+		}
+		if buf.String() != "id,title,latitude,happened_at,tags\n" {
+			t.Errorf("Expected only the header, got %q", buf.String())
+		}
+	})
+}
+
+func TestParquetWriter(t *testing.T) {
+	columns, _ := SelectColumns([]string{"title", "latitude", "happened_at"})
+
+	var buf bytes.Buffer
+	w := NewParquetWriter(&buf, columns)
+	for _, event := range testResponse().GetEvents() {
+		if err := w.Write(event); err != nil {
+			t.Fatalf("Failed to write event: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+
+	type row struct {
+		Title      string  `parquet:"title"`
+		Latitude   float64 `parquet:"latitude"`
+		HappenedAt int64   `parquet:"happened_at"`
+	}
+	reader := parquet.NewGenericReader[row](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	rows := make([]row, 3)
+	n, err := reader.Read(rows)
+	if err != nil && err != io.EOF {
+		t.Fatalf("Failed to read Parquet: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("Expected 3 rows, got %d", n)
+	}
+	if rows[0].Title != "Strike <A>" || rows[0].Latitude != 50 || rows[0].HappenedAt != 1700000000 {
+		t.Errorf("Unexpected row: %+v", rows[0])
+	}
+}
+
+func TestChunkWriter(t *testing.T) {
+	var chunks [][]byte
+	w := NewChunkWriter(4, func(data []byte) error {
+		chunks = append(chunks, data)
+		return nil
+	})
+
+	w.Write([]byte("abcdefghij"))
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+
+	if len(chunks) != 3 || string(chunks[0]) != "abcd" || string(chunks[2]) != "ij" {
+		t.Errorf("Unexpected chunks: %q", chunks)
+	}
+}
@@ -0,0 +1,56 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// Point is a WGS84 coordinate in degrees.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// EventPoint returns the coordinate of an event and whether it has one. A
+// location at exactly 0,0 is taken as one with only address fields set,
+// since unset coordinates read as zero.
+func EventPoint(event *model.Event) (Point, bool) {
+	location := event.GetLocation()
+	if location == nil || (location.GetLatitude() == 0 && location.GetLongitude() == 0) {
+		return Point{}, false
+	}
+	return Point{
+		Latitude:  float64(location.GetLatitude()),
+		Longitude: float64(location.GetLongitude()),
+	}, true
+}
+
+// Bounds computes the bounding box that contains all points. It returns nil
+// when there are no points.
+func Bounds(points []Point) *geovision.BoundingBox {
+	if len(points) == 0 {
+		return nil
+	}
+
+	bbox := &geovision.BoundingBox{
+		MinLatitude:  math.Inf(1),
+		MinLongitude: math.Inf(1),
+		MaxLatitude:  math.Inf(-1),
+		MaxLongitude: math.Inf(-1),
+	}
+	for _, p := range points {
+		bbox.MinLatitude = math.Min(bbox.MinLatitude, p.Latitude)
+		bbox.MinLongitude = math.Min(bbox.MinLongitude, p.Longitude)
+		bbox.MaxLatitude = math.Max(bbox.MaxLatitude, p.Latitude)
+		bbox.MaxLongitude = math.Max(bbox.MaxLongitude, p.Longitude)
+	}
+	return bbox
+}
+
+// InBounds reports whether p lies inside the bounding box, edges included.
+func InBounds(bbox *geovision.BoundingBox, p Point) bool {
+	return p.Latitude >= bbox.GetMinLatitude() && p.Latitude <= bbox.GetMaxLatitude() &&
+		p.Longitude >= bbox.GetMinLongitude() && p.Longitude <= bbox.GetMaxLongitude()
+}
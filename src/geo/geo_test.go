@@ -0,0 +1,160 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+func TestBounds(t *testing.T) {
+	if Bounds(nil) != nil {
+		t.Error("Expected nil bounding box for no points")
+	}
+
+	bbox := Bounds([]Point{
+		{Latitude: 50.0, Longitude: 36.2},
+		{Latitude: 48.5, Longitude: 35.0},
+		{Latitude: 49.9, Longitude: 37.5},
+	})
+	if bbox.MinLatitude != 48.5 || bbox.MaxLatitude != 50.0 {
+		t.Errorf("Unexpected latitude bounds: %v..%v", bbox.MinLatitude, bbox.MaxLatitude)
+	}
+	if bbox.MinLongitude != 35.0 || bbox.MaxLongitude != 37.5 {
+		t.Errorf("Unexpected longitude bounds: %v..%v", bbox.MinLongitude, bbox.MaxLongitude)
+	}
+}
+
+func TestEventPoint(t *testing.T) {
+	t.Run("Located", func(t *testing.T) {
+		p, ok := EventPoint(&model.Event{Location: &model.LocationData{Latitude: 50, Longitude: 0}})
+		if !ok || p.Latitude != 50 || p.Longitude != 0 {
+			t.Errorf("Expected 50,0, got %v and %v", p, ok)
+		}
+	})
+
+	t.Run("Unlocated", func(t *testing.T) {
+		for _, event := range []*model.Event{
+			{},
+			{Location: &model.LocationData{Address: "1 Main Street", CountryCode: "UA"}},
+		} {
+			if p, ok := EventPoint(event); ok {
+				t.Errorf("Expected no point for %v, got %v", event.GetLocation(), p)
+			}
+		}
+	})
+}
+
+func TestTrack(t *testing.T) {
+	events := []*model.Event{
+		{Id: "events/1", HappenedAt: 1000, Location: &model.LocationData{Latitude: 50, Longitude: 36}},
+		{Id: "events/2", HappenedAt: 1500},
+		{Id: "events/3", HappenedAt: 2000, Location: &model.LocationData{Latitude: 49, Longitude: 35}},
+	}
+
+	track, err := Track(events)
+	if err != nil {
+		t.Fatalf("Failed to build track: %v", err)
+	}
+
+	features := track.GetFields()["features"].GetListValue().GetValues()
+	if len(features) != 3 {
+		t.Fatalf("Expected a LineString and 2 Points, got %d features", len(features))
+	}
+
+	line := features[0].GetStructValue().GetFields()["geometry"].GetStructValue()
+	if line.GetFields()["type"].GetStringValue() != "LineString" {
+		t.Errorf("Expected first feature to be a LineString, got %s", line.GetFields()["type"].GetStringValue())
+	}
+
+	coordinates := line.GetFields()["coordinates"].GetListValue().GetValues()
+	if len(coordinates) != 2 {
+		t.Errorf("Expected 2 track coordinates, got %d", len(coordinates))
+	}
+
+	first := coordinates[0].GetListValue().GetValues()
+	if first[0].GetNumberValue() != 36 || first[1].GetNumberValue() != 50 {
+		t.Errorf("Expected first coordinate in [lon, lat] order, got %v", first)
+	}
+
+	empty, err := Track(nil)
+	if err != nil {
+		t.Fatalf("Failed to build empty track: %v", err)
+	}
+	if n := len(empty.GetFields()["features"].GetListValue().GetValues()); n != 0 {
+		t.Errorf("Expected empty track to have no features, got %d", n)
+	}
+}
+
+func TestGeohash(t *testing.T) {
+	tests := []struct {
+		point     Point
+		precision int
+		want      string
+	}{
+		{Point{Latitude: 57.64911, Longitude: 10.40744}, 11, "u4pruydqqvj"},
+		{Point{Latitude: 50.0, Longitude: 36.23}, 5, "ubcu2"},
+		{Point{Latitude: -33.8688, Longitude: 151.2093}, 4, "r3gx"},
+	}
+
+	for _, tt := range tests {
+		if got := Geohash(tt.point, tt.precision); got != tt.want {
+			t.Errorf("Geohash(%v, %d) = %s, want %s", tt.point, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	square := Ring{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}}
+	hole := Ring{{4, 4}, {4, 6}, {6, 6}, {6, 4}, {4, 4}}
+	shape := MultiPolygon{Polygon{square, hole}}
+
+	if !shape.Contains(Point{Latitude: 2, Longitude: 2}) {
+		t.Error("Expected point inside the square")
+	}
+	if shape.Contains(Point{Latitude: 5, Longitude: 5}) {
+		t.Error("Expected point in the hole to be outside")
+	}
+	if shape.Contains(Point{Latitude: 12, Longitude: 5}) {
+		t.Error("Expected point beyond the square to be outside")
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	// About 111 meters between points; the second point is 1 meter off the
+	// line and the fourth 111 meters
+	line := []Point{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0.00001, Longitude: 0.001},
+		{Latitude: 0, Longitude: 0.002},
+		{Latitude: 0.001, Longitude: 0.003},
+		{Latitude: 0, Longitude: 0.004},
+	}
+
+	kept := Simplify(line, 10)
+	if len(kept) != 4 || kept[1] != 2 || kept[2] != 3 {
+		t.Errorf("Expected only point 1 to be dropped, got %v", kept)
+	}
+
+	if kept := Simplify(line, 0); len(kept) != len(line) {
+		t.Errorf("Expected every point to be kept without tolerance, got %v", kept)
+	}
+	if kept := Simplify(line, 1000); len(kept) != 2 {
+		t.Errorf("Expected only the end points to be kept, got %v", kept)
+	}
+}
+
+func TestGeohashCell(t *testing.T) {
+	point := Point{Latitude: -33.8688, Longitude: 151.2093}
+	latCells, lonCells := GeohashGrid(4)
+	if latCells != 1<<10 || lonCells != 1<<10 {
+		t.Fatalf("Expected a 1024x1024 grid, got %dx%d", latCells, lonCells)
+	}
+	row := int64((point.Latitude + 90) / 180 * float64(latCells))
+	column := int64((point.Longitude + 180) / 360 * float64(lonCells))
+	if got := GeohashCell(row, column, 4); got != "r3gx" {
+		t.Errorf("Expected cell r3gx, got %s", got)
+	}
+	if latCells, lonCells := GeohashGrid(5); latCells != 1<<12 || lonCells != 1<<13 {
+		t.Errorf("Expected a 4096x8192 grid, got %dx%d", latCells, lonCells)
+	}
+}
@@ -0,0 +1,61 @@
+package geo
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// GeohashGrid returns the number of latitude and longitude cells that
+// geohashes with the given number of characters split the world into.
+func GeohashGrid(precision int) (int64, int64) {
+	bits := 5 * precision
+	return 1 << (bits / 2), 1 << ((bits + 1) / 2)
+}
+
+// GeohashCell returns the geohash of the cell at the given row and column of
+// the grid of GeohashGrid.
+func GeohashCell(row, column int64, precision int) string {
+	latCells, lonCells := GeohashGrid(precision)
+	return Geohash(Point{
+		Latitude:  -90 + (float64(row)+0.5)*180/float64(latCells),
+		Longitude: -180 + (float64(column)+0.5)*360/float64(lonCells),
+	}, precision)
+}
+
+// Geohash encodes a point as a geohash string with the given number of
+// characters.
+func Geohash(p Point, precision int) string {
+	minLat, maxLat := -90.0, 90.0
+	minLon, maxLon := -180.0, 180.0
+
+	hash := make([]byte, 0, precision)
+	even := true
+	bit, ch := 0, 0
+
+	for len(hash) < precision {
+		if even {
+			mid := (minLon + maxLon) / 2
+			if p.Longitude >= mid {
+				ch |= 1 << (4 - bit)
+				minLon = mid
+			} else {
+				maxLon = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if p.Latitude >= mid {
+				ch |= 1 << (4 - bit)
+				minLat = mid
+			} else {
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			hash = append(hash, geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return string(hash)
+}
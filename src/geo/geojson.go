@@ -0,0 +1,165 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// position encodes a point in GeoJSON order (longitude first).
+func position(p Point) []interface{} {
+	return []interface{}{p.Longitude, p.Latitude}
+}
+
+// bboxArray encodes a bounding box in GeoJSON order (west, south, east, north).
+func bboxArray(bbox *geovision.BoundingBox) []interface{} {
+	return []interface{}{bbox.MinLongitude, bbox.MinLatitude, bbox.MaxLongitude, bbox.MaxLatitude}
+}
+
+// PointFeature builds a GeoJSON Point feature for an event.
+func PointFeature(event *model.Event, p Point) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "Feature",
+		"id":   event.GetId(),
+		"geometry": map[string]interface{}{
+			"type":        "Point",
+			"coordinates": position(p),
+		},
+		"properties": map[string]interface{}{
+			"title":       event.GetTitle(),
+			"happened_at": event.GetHappenedAt(),
+		},
+	}
+}
+
+// Track builds a GeoJSON FeatureCollection containing a LineString through
+// the located events in the given order followed by one Point per event.
+// Events without a location are skipped.
+func Track(events []*model.Event) (*structpb.Struct, error) {
+	var points []Point
+	var coordinates []interface{}
+	var features []interface{}
+
+	for _, event := range events {
+		p, ok := EventPoint(event)
+		if !ok {
+			continue
+		}
+		points = append(points, p)
+		coordinates = append(coordinates, position(p))
+		features = append(features, PointFeature(event, p))
+	}
+
+	if len(coordinates) > 1 {
+		line := map[string]interface{}{
+			"type": "Feature",
+			"geometry": map[string]interface{}{
+				"type":        "LineString",
+				"coordinates": coordinates,
+			},
+			"properties": map[string]interface{}{},
+		}
+		features = append([]interface{}{line}, features...)
+	}
+
+	collection := map[string]interface{}{
+		"type":     "FeatureCollection",
+		"features": features,
+	}
+	if bbox := Bounds(points); bbox != nil {
+		collection["bbox"] = bboxArray(bbox)
+	}
+	if features == nil {
+		collection["features"] = []interface{}{}
+	}
+
+	return structpb.NewStruct(collection)
+}
+
+// FeatureCollection is a decoded GeoJSON FeatureCollection.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a decoded GeoJSON Feature.
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry is a GeoJSON geometry whose coordinates are decoded on demand.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// Point decodes a Point geometry.
+func (g *Geometry) Point() (Point, error) {
+	if g == nil || g.Type != "Point" {
+		return Point{}, fmt.Errorf("expected Point geometry")
+	}
+	var coordinates []float64
+	if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+		return Point{}, fmt.Errorf("invalid Point coordinates: %v", err)
+	}
+	return toPoint(coordinates)
+}
+
+// MultiPolygon decodes a Polygon or MultiPolygon geometry.
+func (g *Geometry) MultiPolygon() (MultiPolygon, error) {
+	if g == nil {
+		return nil, fmt.Errorf("missing geometry")
+	}
+
+	var polygons [][][][]float64
+	switch g.Type {
+	case "Polygon":
+		var polygon [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polygon); err != nil {
+			return nil, fmt.Errorf("invalid Polygon coordinates: %v", err)
+		}
+		polygons = [][][][]float64{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(g.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("invalid MultiPolygon coordinates: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("expected Polygon or MultiPolygon geometry, got %s", g.Type)
+	}
+
+	mp := make(MultiPolygon, 0, len(polygons))
+	for _, polygon := range polygons {
+		poly := make(Polygon, 0, len(polygon))
+		for _, ring := range polygon {
+			r := make(Ring, 0, len(ring))
+			for _, coordinates := range ring {
+				p, err := toPoint(coordinates)
+				if err != nil {
+					return nil, err
+				}
+				r = append(r, p)
+			}
+			poly = append(poly, r)
+		}
+		mp = append(mp, poly)
+	}
+	return mp, nil
+}
+
+// toPoint converts a GeoJSON position (longitude first) to a point.
+func toPoint(coordinates []float64) (Point, error) {
+	if len(coordinates) < 2 {
+		return Point{}, fmt.Errorf("position must have at least 2 coordinates")
+	}
+	p := Point{Latitude: coordinates[1], Longitude: coordinates[0]}
+	if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
+		return Point{}, fmt.Errorf("position %v is out of range", coordinates)
+	}
+	return p, nil
+}
@@ -0,0 +1,59 @@
+package geo
+
+import "github.com/omnsight/geovision/gen/geovision/v1"
+
+// Ring is a closed sequence of points; the last point may repeat the first.
+type Ring []Point
+
+// Polygon is an outer ring followed by zero or more holes.
+type Polygon []Ring
+
+// MultiPolygon is a set of polygons treated as one area.
+type MultiPolygon []Polygon
+
+// Contains reports whether p lies inside the ring using the even-odd rule.
+func (r Ring) Contains(p Point) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Contains reports whether p lies inside the outer ring and outside all holes.
+func (poly Polygon) Contains(p Point) bool {
+	if len(poly) == 0 || !poly[0].Contains(p) {
+		return false
+	}
+	for _, hole := range poly[1:] {
+		if hole.Contains(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether p lies inside any of the polygons.
+func (mp MultiPolygon) Contains(p Point) bool {
+	for _, poly := range mp {
+		if poly.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the bounding box of all outer rings, or nil when empty.
+func (mp MultiPolygon) Bounds() *geovision.BoundingBox {
+	var points []Point
+	for _, poly := range mp {
+		if len(poly) > 0 {
+			points = append(points, poly[0]...)
+		}
+	}
+	return Bounds(points)
+}
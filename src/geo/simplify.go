@@ -0,0 +1,77 @@
+package geo
+
+import "math"
+
+// earthRadiusMeters is the mean radius of the Earth.
+const earthRadiusMeters = 6371008.8
+
+// Simplify reduces a line with the Douglas–Peucker algorithm and returns the
+// indices of the points to keep, in order. Points closer than tolerance
+// meters to the simplified line are dropped; the end points are always kept.
+func Simplify(points []Point, tolerance float64) []int {
+	if len(points) <= 2 || tolerance <= 0 {
+		kept := make([]int, len(points))
+		for i := range points {
+			kept[i] = i
+		}
+		return kept
+	}
+
+	// Project onto a plane around the first point, which is accurate enough
+	// at track scale and makes distances plain Euclidean math
+	origin := points[0]
+	cosLat := math.Cos(origin.Latitude * math.Pi / 180)
+	xs := make([]float64, len(points))
+	ys := make([]float64, len(points))
+	for i, p := range points {
+		xs[i] = (p.Longitude - origin.Longitude) * math.Pi / 180 * earthRadiusMeters * cosLat
+		ys[i] = (p.Latitude - origin.Latitude) * math.Pi / 180 * earthRadiusMeters
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	// Iterative to avoid deep recursion on long tracks
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		first, last := span[0], span[1]
+
+		farthest, maxDistance := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			d := segmentDistance(xs[i], ys[i], xs[first], ys[first], xs[last], ys[last])
+			if d > maxDistance {
+				farthest, maxDistance = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+
+		keep[farthest] = true
+		stack = append(stack, [2]int{first, farthest}, [2]int{farthest, last})
+	}
+
+	var kept []int
+	for i, k := range keep {
+		if k {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
+
+// segmentDistance is the distance from (px, py) to the segment from (ax, ay)
+// to (bx, by).
+func segmentDistance(px, py, ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	lengthSquared := dx*dx + dy*dy
+	if lengthSquared == 0 {
+		return math.Hypot(px-ax, py-ay)
+	}
+
+	t := ((px-ax)*dx + (py-ay)*dy) / lengthSquared
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(px-(ax+t*dx), py-(ay+t*dy))
+}
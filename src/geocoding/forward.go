@@ -0,0 +1,348 @@
+package geocoding
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultGazetteerPath is the location of the GeoNames dump inside the image.
+const DefaultGazetteerPath = "data/gazetteer/cities15000.txt"
+
+// GeoNames dump columns, see https://download.geonames.org/export/dump/
+const (
+	colGeonameID = iota
+	colName
+	colASCIIName
+	colAlternateNames
+	colLatitude
+	colLongitude
+	colFeatureClass
+	colFeatureCode
+	colCountryCode
+	colCC2
+	colAdmin1Code
+	colAdmin2Code
+	colAdmin3Code
+	colAdmin4Code
+	colPopulation
+	geonamesColumns
+)
+
+// Match quality, best first.
+const (
+	matchExact = iota
+	matchPrefix
+	matchFuzzy
+)
+
+// trigramPad pads names so that their first and last letters start and end
+// trigrams of their own.
+const trigramPad = '\x00'
+
+// Gazetteer is an in-memory index of named places supporting prefix and
+// fuzzy lookups.
+type Gazetteer struct {
+	places []*geovision.Place
+	// names are sorted, so names sharing a prefix are contiguous
+	names []indexedName
+	// trigrams lists the names holding each trigram, and lengths the names
+	// of each length in runes, for fuzzy lookups
+	trigrams map[trigram][]int32
+	lengths  map[int][]int32
+}
+
+// indexedName is one normalized name or alias of a place.
+type indexedName struct {
+	name  string
+	runes []rune
+	place int
+}
+
+// trigram is three consecutive runes of a padded name.
+type trigram [3]rune
+
+// candidate is a place matched by a query.
+type candidate struct {
+	place int
+	match int
+}
+
+// NewGazetteer loads a GeoNames-style tab separated dump.
+func NewGazetteer(path string) (*Gazetteer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gazetteer := &Gazetteer{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < geonamesColumns {
+			continue
+		}
+
+		place, err := parsePlace(fields)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gazetteer line %d: %v", line, err)
+		}
+
+		index := len(gazetteer.places)
+		gazetteer.places = append(gazetteer.places, place)
+
+		seen := make(map[string]bool)
+		names := append([]string{fields[colName], fields[colASCIIName]}, strings.Split(fields[colAlternateNames], ",")...)
+		for _, name := range names {
+			normalized := normalize(name)
+			if normalized == "" || seen[normalized] {
+				continue
+			}
+			seen[normalized] = true
+			gazetteer.names = append(gazetteer.names, indexedName{name: normalized, runes: []rune(normalized), place: index})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(gazetteer.names, func(i, j int) bool {
+		return gazetteer.names[i].name < gazetteer.names[j].name
+	})
+	gazetteer.indexTrigrams()
+	return gazetteer, nil
+}
+
+// indexTrigrams builds the postings lists of the fuzzy index.
+func (g *Gazetteer) indexTrigrams() {
+	g.trigrams = make(map[trigram][]int32)
+	g.lengths = make(map[int][]int32)
+	for i, n := range g.names {
+		for _, t := range trigrams(n.runes) {
+			g.trigrams[t] = append(g.trigrams[t], int32(i))
+		}
+		g.lengths[len(n.runes)] = append(g.lengths[len(n.runes)], int32(i))
+	}
+}
+
+// trigrams returns the distinct trigrams of a padded name.
+func trigrams(name []rune) []trigram {
+	padded := make([]rune, 0, len(name)+4)
+	padded = append(padded, trigramPad, trigramPad)
+	padded = append(padded, name...)
+	padded = append(padded, trigramPad, trigramPad)
+
+	seen := make(map[trigram]bool, len(padded))
+	result := make([]trigram, 0, len(padded)-2)
+	for i := 0; i+3 <= len(padded); i++ {
+		t := trigram{padded[i], padded[i+1], padded[i+2]}
+		if !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// fuzzyCandidates returns the names that may be within maxDistance edits of
+// the query. An edit changes at most three trigrams, so such names share all
+// but 3*maxDistance of the trigrams of the query; queries with too few
+// trigrams for that to narrow anything down take every name of a close
+// enough length instead.
+func (g *Gazetteer) fuzzyCandidates(q []rune, maxDistance int) []int32 {
+	grams := trigrams(q)
+	required := len(grams) - 3*maxDistance
+	if required <= 0 {
+		var candidates []int32
+		for length := max(len(q)-maxDistance, 0); length <= len(q)+maxDistance; length++ {
+			candidates = append(candidates, g.lengths[length]...)
+		}
+		return candidates
+	}
+
+	shared := make(map[int32]int)
+	for _, t := range grams {
+		for _, i := range g.trigrams[t] {
+			shared[i]++
+		}
+	}
+	var candidates []int32
+	for i, count := range shared {
+		if count >= required {
+			candidates = append(candidates, i)
+		}
+	}
+	return candidates
+}
+
+// Search returns up to limit places matching the query, exact matches first,
+// then prefix matches, then fuzzy matches, each ordered by population. An
+// empty country code matches every country.
+func (g *Gazetteer) Search(query, countryCode string, limit int) []*geovision.Place {
+	q := normalize(query)
+	if q == "" || limit <= 0 {
+		return nil
+	}
+
+	best := make(map[int]int)
+	consider := func(place, match int) {
+		if countryCode != "" && !strings.EqualFold(g.places[place].GetCountryCode(), countryCode) {
+			return
+		}
+		if current, ok := best[place]; !ok || match < current {
+			best[place] = match
+		}
+	}
+
+	// Names sharing the query as prefix are contiguous in the sorted index
+	start := sort.Search(len(g.names), func(i int) bool { return g.names[i].name >= q })
+	for i := start; i < len(g.names) && strings.HasPrefix(g.names[i].name, q); i++ {
+		if g.names[i].name == q {
+			consider(g.names[i].place, matchExact)
+		} else {
+			consider(g.names[i].place, matchPrefix)
+		}
+	}
+
+	// Only fall back to typo tolerance when the prefix scan is not enough
+	if len(best) < limit {
+		qr := []rune(q)
+		maxDistance := 1
+		if len(qr) > 5 {
+			maxDistance = 2
+		}
+		for _, i := range g.fuzzyCandidates(qr, maxDistance) {
+			n := g.names[i]
+			if _, ok := best[n.place]; ok {
+				continue
+			}
+			if levenshtein(qr, n.runes, maxDistance) <= maxDistance {
+				consider(n.place, matchFuzzy)
+			}
+		}
+	}
+
+	candidates := make([]candidate, 0, len(best))
+	for place, match := range best {
+		candidates = append(candidates, candidate{place: place, match: match})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.match != b.match {
+			return a.match < b.match
+		}
+		pa, pb := g.places[a.place].GetPopulation(), g.places[b.place].GetPopulation()
+		if pa != pb {
+			return pa > pb
+		}
+		return a.place < b.place
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	places := make([]*geovision.Place, 0, len(candidates))
+	for _, c := range candidates {
+		places = append(places, g.places[c.place])
+	}
+	return places
+}
+
+func parsePlace(fields []string) (*geovision.Place, error) {
+	id, err := strconv.ParseInt(fields[colGeonameID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid geoname id: %v", err)
+	}
+	lat, err := strconv.ParseFloat(fields[colLatitude], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude: %v", err)
+	}
+	lon, err := strconv.ParseFloat(fields[colLongitude], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude: %v", err)
+	}
+	population, _ := strconv.ParseInt(fields[colPopulation], 10, 64)
+
+	return &geovision.Place{
+		GeonameId:              id,
+		Name:                   fields[colName],
+		CountryCode:            fields[colCountryCode],
+		AdministrativeAreaCode: fields[colAdmin1Code],
+		FeatureCode:            fields[colFeatureClass] + "." + fields[colFeatureCode],
+		Latitude:               lat,
+		Longitude:              lon,
+		Population:             population,
+		Bbox:                   approximateBounds(lat, lon, population),
+	}, nil
+}
+
+// approximateBounds estimates the extent of a place from its population since
+// the gazetteer only carries a center point. A city of one million gets a
+// radius of about 22 km.
+func approximateBounds(lat, lon float64, population int64) *geovision.BoundingBox {
+	radiusKm := 2 + math.Sqrt(float64(population))/50
+	dLat := radiusKm / 111.32
+	dLon := dLat / math.Max(math.Cos(lat*math.Pi/180), 0.01)
+
+	return &geovision.BoundingBox{
+		MinLatitude:  math.Max(lat-dLat, -90),
+		MinLongitude: math.Max(lon-dLon, -180),
+		MaxLatitude:  math.Min(lat+dLat, 90),
+		MaxLongitude: math.Min(lon+dLon, 180),
+	}
+}
+
+// normalize lowercases a name and strips diacritics so "Kyïv" matches "kyiv".
+func normalize(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, strings.TrimSpace(name))
+	if err != nil {
+		result = name
+	}
+	return strings.ToLower(result)
+}
+
+// levenshtein computes the edit distance between a and b, giving up with
+// limit+1 as soon as the distance is known to exceed limit.
+func levenshtein(a, b []rune, limit int) int {
+	if d := len(a) - len(b); d > limit || -d > limit {
+		return limit + 1
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
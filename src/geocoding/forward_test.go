@@ -0,0 +1,90 @@
+package geocoding
+
+import (
+	"strings"
+	"testing"
+)
+
+var testGazetteer = strings.Join([]string{
+	"706483\tKharkiv\tKharkiv\tCharkiw,Kharkov,Харків\t49.98081\t36.25272\tP\tPPLA\tUA\t\t07\t\t\t\t1430885\t\t152\tEurope/Kyiv\t2023-01-01",
+	"703448\tKyiv\tKyiv\tKiev,Kyïv,Київ\t50.45466\t30.5238\tP\tPPLC\tUA\t\t12\t\t\t\t2797553\t\t187\tEurope/Kyiv\t2023-01-01",
+	"4717560\tKharkov\tKharkov\t\t35.0\t-90.0\tP\tPPL\tUS\t\tTN\t\t\t\t120\t\t100\tAmerica/Chicago\t2023-01-01",
+	"short\tline",
+}, "\n")
+
+func TestGazetteer(t *testing.T) {
+	gazetteer, err := NewGazetteer(writeFile(t, "cities.txt", testGazetteer))
+	if err != nil {
+		t.Fatalf("Failed to create gazetteer: %v", err)
+	}
+
+	t.Run("Exact and alias", func(t *testing.T) {
+		places := gazetteer.Search("kharkov", "", 10)
+		if len(places) != 2 {
+			t.Fatalf("Expected 2 places, got %d", len(places))
+		}
+		// Both match exactly, so the larger city wins
+		if places[0].GetName() != "Kharkiv" {
+			t.Errorf("Expected Kharkiv first, got %s", places[0].GetName())
+		}
+	})
+
+	t.Run("Country filter", func(t *testing.T) {
+		places := gazetteer.Search("Kharkov", "us", 10)
+		if len(places) != 1 || places[0].GetCountryCode() != "US" {
+			t.Errorf("Expected only the US place, got %v", places)
+		}
+	})
+
+	t.Run("Prefix and diacritics", func(t *testing.T) {
+		places := gazetteer.Search("Kyi", "", 10)
+		if len(places) != 1 || places[0].GetName() != "Kyiv" {
+			t.Fatalf("Expected Kyiv, got %v", places)
+		}
+		if places[0].GetAdministrativeAreaCode() != "12" || places[0].GetFeatureCode() != "P.PPLC" {
+			t.Errorf("Unexpected place fields: %v", places[0])
+		}
+
+		bbox := places[0].GetBbox()
+		if bbox.GetMinLatitude() >= 50.45466 || bbox.GetMaxLatitude() <= 50.45466 {
+			t.Errorf("Expected bbox around the city, got %v", bbox)
+		}
+	})
+
+	t.Run("Fuzzy", func(t *testing.T) {
+		places := gazetteer.Search("Kharkic", "", 10)
+		if len(places) == 0 || places[0].GetName() != "Kharkiv" {
+			t.Errorf("Expected Kharkiv for a typo, got %v", places)
+		}
+
+		if places := gazetteer.Search("Lviv", "", 10); len(places) != 0 {
+			t.Errorf("Expected no match, got %v", places)
+		}
+	})
+
+	t.Run("Fuzzy Index", func(t *testing.T) {
+		// The index must never miss a name a full scan would find
+		for _, query := range []string{"harkiv", "kharkiw", "kyv", "k", "kievv", "xarkov"} {
+			q := []rune(query)
+			maxDistance := 1
+			if len(q) > 5 {
+				maxDistance = 2
+			}
+			candidates := make(map[int32]bool)
+			for _, i := range gazetteer.fuzzyCandidates(q, maxDistance) {
+				candidates[i] = true
+			}
+			for i, n := range gazetteer.names {
+				if levenshtein(q, n.runes, maxDistance) <= maxDistance && !candidates[int32(i)] {
+					t.Errorf("Expected %q to be a candidate for %q", n.name, query)
+				}
+			}
+		}
+
+		for _, i := range gazetteer.fuzzyCandidates([]rune("kharkic"), 2) {
+			if name := gazetteer.names[i].name; name == "kyiv" {
+				t.Errorf("Expected no candidate sharing no trigram, got %q", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package geocoding
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// Default locations of the Natural Earth datasets inside the image.
+const (
+	DefaultAdmin0BoundariesPath = "data/boundaries/ne_10m_admin_0_countries.geojson"
+	DefaultAdmin1BoundariesPath = "data/boundaries/ne_10m_admin_1_states_provinces.geojson"
+)
+
+// Natural Earth property names, in order of preference.
+var (
+	countryCodeProperties = []string{"ISO_A2_EH", "ISO_A2", "iso_a2"}
+	adminNameProperties   = []string{"name", "NAME", "name_en"}
+)
+
+// Place is the administrative area a point falls into.
+type Place struct {
+	CountryCode        string
+	AdministrativeArea string
+}
+
+// boundary is one administrative area with its shape.
+type boundary struct {
+	countryCode string
+	name        string
+	shape       geo.MultiPolygon
+}
+
+// ReverseGeocoder resolves coordinates to countries and first-level
+// administrative areas from in-memory boundary polygons.
+type ReverseGeocoder struct {
+	countries *boundaryIndex
+	admin1    *boundaryIndex
+}
+
+// NewReverseGeocoder loads the admin-0 (countries) and admin-1 (states and
+// provinces) GeoJSON datasets. The admin-1 path may be empty.
+func NewReverseGeocoder(admin0Path, admin1Path string) (*ReverseGeocoder, error) {
+	countries, err := loadBoundaries(admin0Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load admin-0 boundaries: %v", err)
+	}
+
+	geocoder := &ReverseGeocoder{countries: newBoundaryIndex(countries)}
+	if admin1Path != "" {
+		admin1, err := loadBoundaries(admin1Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load admin-1 boundaries: %v", err)
+		}
+		geocoder.admin1 = newBoundaryIndex(admin1)
+	}
+	return geocoder, nil
+}
+
+// Lookup returns the place containing p and whether one was found.
+func (g *ReverseGeocoder) Lookup(p geo.Point) (Place, bool) {
+	var place Place
+	if b := g.countries.find(p); b != nil {
+		place.CountryCode = b.countryCode
+	}
+	if g.admin1 != nil {
+		if b := g.admin1.find(p); b != nil {
+			place.AdministrativeArea = b.name
+			if place.CountryCode == "" {
+				place.CountryCode = b.countryCode
+			}
+		}
+	}
+	return place, place != Place{}
+}
+
+// Fill sets the country code and administrative area of the location when
+// they are empty. It reports whether anything was changed.
+func (g *ReverseGeocoder) Fill(location *model.LocationData) bool {
+	if location == nil || (location.GetCountryCode() != "" && location.GetAdministrativeArea() != "") {
+		return false
+	}
+
+	place, ok := g.Lookup(geo.Point{
+		Latitude:  float64(location.GetLatitude()),
+		Longitude: float64(location.GetLongitude()),
+	})
+	if !ok {
+		return false
+	}
+
+	changed := false
+	if location.CountryCode == "" && place.CountryCode != "" {
+		location.CountryCode = place.CountryCode
+		changed = true
+	}
+	if location.AdministrativeArea == "" && place.AdministrativeArea != "" {
+		location.AdministrativeArea = place.AdministrativeArea
+		changed = true
+	}
+	return changed
+}
+
+// loadBoundaries reads a GeoJSON FeatureCollection of Polygon and
+// MultiPolygon features. Features with other geometries are skipped.
+func loadBoundaries(path string) ([]*boundary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var collection geo.FeatureCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON in %s: %v", path, err)
+	}
+
+	boundaries := make([]*boundary, 0, len(collection.Features))
+	for _, feature := range collection.Features {
+		shape, err := feature.Geometry.MultiPolygon()
+		if err != nil {
+			continue
+		}
+		boundaries = append(boundaries, &boundary{
+			countryCode: stringProperty(feature.Properties, countryCodeProperties),
+			name:        stringProperty(feature.Properties, adminNameProperties),
+			shape:       shape,
+		})
+	}
+	return boundaries, nil
+}
+
+// stringProperty returns the first usable value among the given properties.
+// Natural Earth uses "-99" for unknown codes.
+func stringProperty(properties map[string]interface{}, names []string) string {
+	for _, name := range names {
+		if value, ok := properties[name].(string); ok && value != "" && value != "-99" {
+			return value
+		}
+	}
+	return ""
+}
+
+// boundaryIndex buckets boundaries into one-degree cells by bounding box so
+// a lookup only tests the polygons that can contain the point.
+type boundaryIndex struct {
+	cells map[int][]*boundary
+}
+
+func newBoundaryIndex(boundaries []*boundary) *boundaryIndex {
+	index := &boundaryIndex{cells: make(map[int][]*boundary)}
+	for _, b := range boundaries {
+		bbox := b.shape.Bounds()
+		if bbox == nil {
+			continue
+		}
+		for lat := cellLatitude(bbox.MinLatitude); lat <= cellLatitude(bbox.MaxLatitude); lat++ {
+			for lon := cellLongitude(bbox.MinLongitude); lon <= cellLongitude(bbox.MaxLongitude); lon++ {
+				key := lat*360 + lon
+				index.cells[key] = append(index.cells[key], b)
+			}
+		}
+	}
+	return index
+}
+
+func (index *boundaryIndex) find(p geo.Point) *boundary {
+	key := cellLatitude(p.Latitude)*360 + cellLongitude(p.Longitude)
+	for _, b := range index.cells[key] {
+		if b.shape.Contains(p) {
+			return b
+		}
+	}
+	return nil
+}
+
+func cellLatitude(lat float64) int {
+	return int(math.Min(math.Floor(lat+90), 179))
+}
+
+func cellLongitude(lon float64) int {
+	return int(math.Min(math.Floor(lon+180), 359))
+}
@@ -0,0 +1,98 @@
+package geocoding
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+const testCountries = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"properties": {"ISO_A2_EH": "UA", "ISO_A2": "-99"},
+			"geometry": {"type": "Polygon", "coordinates": [[[22, 44], [40, 44], [40, 52], [22, 52], [22, 44]]]}
+		},
+		{
+			"type": "Feature",
+			"properties": {"ISO_A2": "XX"},
+			"geometry": {"type": "Point", "coordinates": [0, 0]}
+		}
+	]
+}`
+
+const testStates = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"properties": {"iso_a2": "UA", "name": "Kharkiv"},
+			"geometry": {"type": "MultiPolygon", "coordinates": [[[[35, 49], [38, 49], [38, 51], [35, 51], [35, 49]]]]}
+		}
+	]
+}`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestReverseGeocoder(t *testing.T) {
+	geocoder, err := NewReverseGeocoder(writeFile(t, "countries.geojson", testCountries), writeFile(t, "states.geojson", testStates))
+	if err != nil {
+		t.Fatalf("Failed to create reverse geocoder: %v", err)
+	}
+
+	t.Run("Lookup", func(t *testing.T) {
+		place, ok := geocoder.Lookup(geo.Point{Latitude: 50.0, Longitude: 36.23})
+		if !ok {
+			t.Fatal("Expected Kharkiv to be found")
+		}
+		if place.CountryCode != "UA" || place.AdministrativeArea != "Kharkiv" {
+			t.Errorf("Unexpected place: %+v", place)
+		}
+
+		place, ok = geocoder.Lookup(geo.Point{Latitude: 46.48, Longitude: 30.72})
+		if !ok || place.CountryCode != "UA" || place.AdministrativeArea != "" {
+			t.Errorf("Expected country without admin area, got %+v", place)
+		}
+
+		if _, ok := geocoder.Lookup(geo.Point{Latitude: 0, Longitude: 0}); ok {
+			t.Error("Expected no place in the ocean")
+		}
+	})
+
+	t.Run("Fill", func(t *testing.T) {
+		location := &model.LocationData{Latitude: 50.0, Longitude: 36.23}
+		if !geocoder.Fill(location) {
+			t.Error("Expected location to be filled")
+		}
+		if location.CountryCode != "UA" || location.AdministrativeArea != "Kharkiv" {
+			t.Errorf("Unexpected location: %v", location)
+		}
+
+		location = &model.LocationData{Latitude: 50.0, Longitude: 36.23, CountryCode: "RU"}
+		geocoder.Fill(location)
+		if location.CountryCode != "RU" {
+			t.Errorf("Expected existing country code to be kept, got %s", location.CountryCode)
+		}
+
+		if geocoder.Fill(nil) {
+			t.Error("Expected nil location to be left alone")
+		}
+	})
+
+	t.Run("Missing dataset", func(t *testing.T) {
+		if _, err := NewReverseGeocoder(filepath.Join(t.TempDir(), "missing.geojson"), ""); err == nil {
+			t.Error("Expected error for missing dataset")
+		}
+	})
+}
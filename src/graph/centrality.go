@@ -0,0 +1,171 @@
+package graph
+
+import "math"
+
+// PageRank parameters.
+const (
+	pageRankDamping    = 0.85
+	pageRankIterations = 100
+	pageRankTolerance  = 1e-9
+)
+
+// Centrality holds the centrality scores of every node, indexed like
+// Graph.Nodes. Relations are treated as undirected: who points at whom says
+// little about how central an entity is to the activity.
+type Centrality struct {
+	// Degree is the number of distinct neighbours
+	Degree []int
+	// Betweenness is the share of shortest paths between other nodes that go
+	// through the node, normalized to 0..1
+	Betweenness []float64
+	// PageRank sums to 1 over the graph
+	PageRank []float64
+}
+
+// Centrality computes degree, betweenness and PageRank for every node.
+func (g *Graph) Centrality() *Centrality {
+	neighbours := g.neighbours()
+	c := &Centrality{
+		Degree:      make([]int, len(g.Nodes)),
+		Betweenness: betweenness(neighbours),
+		PageRank:    pageRank(neighbours),
+	}
+	for i, n := range neighbours {
+		c.Degree[i] = len(n)
+	}
+	return c
+}
+
+// neighbours returns the distinct neighbours of each node, ignoring edge
+// direction and self loops.
+func (g *Graph) neighbours() [][]int {
+	neighbours := make([][]int, len(g.Nodes))
+	seen := make(map[[2]int]bool)
+	for _, edge := range g.Edges {
+		from, to := g.index[edge.From], g.index[edge.To]
+		if from == to {
+			continue
+		}
+		if from > to {
+			from, to = to, from
+		}
+		if seen[[2]int{from, to}] {
+			continue
+		}
+		seen[[2]int{from, to}] = true
+		neighbours[from] = append(neighbours[from], to)
+		neighbours[to] = append(neighbours[to], from)
+	}
+	return neighbours
+}
+
+// betweenness runs Brandes' algorithm on the unweighted undirected graph.
+func betweenness(neighbours [][]int) []float64 {
+	n := len(neighbours)
+	scores := make([]float64, n)
+
+	sigma := make([]float64, n)
+	distance := make([]int, n)
+	delta := make([]float64, n)
+	predecessors := make([][]int, n)
+	order := make([]int, 0, n)
+	queue := make([]int, 0, n)
+
+	for source := 0; source < n; source++ {
+		for i := range sigma {
+			sigma[i], distance[i], delta[i] = 0, -1, 0
+			predecessors[i] = predecessors[i][:0]
+		}
+		sigma[source], distance[source] = 1, 0
+		order, queue = order[:0], append(queue[:0], source)
+
+		// Count shortest paths breadth first
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			order = append(order, v)
+			for _, w := range neighbours[v] {
+				if distance[w] < 0 {
+					distance[w] = distance[v] + 1
+					queue = append(queue, w)
+				}
+				if distance[w] == distance[v]+1 {
+					sigma[w] += sigma[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+
+		// Accumulate dependencies from the farthest nodes back
+		for i := len(order) - 1; i >= 0; i-- {
+			w := order[i]
+			for _, v := range predecessors[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != source {
+				scores[w] += delta[w]
+			}
+		}
+	}
+
+	// Every pair was counted from both ends
+	if n > 2 {
+		pairs := float64(n-1) * float64(n-2)
+		for i := range scores {
+			scores[i] /= pairs
+		}
+	} else {
+		for i := range scores {
+			scores[i] = 0
+		}
+	}
+	return scores
+}
+
+// pageRank iterates PageRank on the undirected graph, spreading the rank of
+// isolated nodes over all nodes.
+func pageRank(neighbours [][]int) []float64 {
+	n := len(neighbours)
+	if n == 0 {
+		return nil
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+
+	for iteration := 0; iteration < pageRankIterations; iteration++ {
+		dangling := 0.0
+		for v, ns := range neighbours {
+			if len(ns) == 0 {
+				dangling += rank[v]
+			}
+		}
+
+		base := (1-pageRankDamping)/float64(n) + pageRankDamping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for v, ns := range neighbours {
+			if len(ns) == 0 {
+				continue
+			}
+			share := pageRankDamping * rank[v] / float64(len(ns))
+			for _, w := range ns {
+				next[w] += share
+			}
+		}
+
+		change := 0.0
+		for i := range rank {
+			change += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if change < pageRankTolerance {
+			break
+		}
+	}
+	return rank
+}
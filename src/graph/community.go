@@ -0,0 +1,207 @@
+package graph
+
+import "sort"
+
+// louvainEpsilon is the smallest gain worth moving a node for, which keeps
+// rounding errors from moving nodes back and forth forever.
+const louvainEpsilon = 1e-12
+
+// Partition splits the nodes of a graph into communities.
+type Partition struct {
+	// Membership is the community of every node, indexed like Graph.Nodes
+	Membership []int
+	// Communities lists the nodes of each community, most connected within
+	// the community first. Communities are numbered by decreasing size.
+	Communities [][]int
+	// Modularity of the partition, from -0.5 to 1
+	Modularity float64
+}
+
+// Communities detects communities with the Louvain method, treating relations
+// as undirected edges of weight one. Nodes are visited in order, so the result
+// is deterministic for a given graph.
+func (g *Graph) Communities() *Partition {
+	neighbours := g.neighbours()
+
+	// The first level is the graph itself
+	adjacency := make([]map[int]float64, len(g.Nodes))
+	for i, ns := range neighbours {
+		adjacency[i] = make(map[int]float64, len(ns))
+		for _, j := range ns {
+			adjacency[i][j] = 1
+		}
+	}
+
+	membership := make([]int, len(g.Nodes))
+	for i := range membership {
+		membership[i] = i
+	}
+
+	for {
+		level, moved := louvainLevel(adjacency)
+		if !moved {
+			break
+		}
+		for i, c := range membership {
+			membership[i] = level[c]
+		}
+		adjacency = aggregate(adjacency, level)
+	}
+
+	return newPartition(neighbours, membership)
+}
+
+// louvainLevel moves nodes between communities while it improves modularity
+// and returns the community of each node, numbered from zero.
+func louvainLevel(adjacency []map[int]float64) ([]int, bool) {
+	n := len(adjacency)
+	community := make([]int, n)
+	degree := make([]float64, n)
+	total := make([]float64, n)
+	weight := 0.0
+	for i, row := range adjacency {
+		community[i] = i
+		for _, w := range row {
+			degree[i] += w
+		}
+		total[i] = degree[i]
+		weight += degree[i]
+	}
+	if weight == 0 {
+		return community, false
+	}
+
+	moved := false
+	links := make(map[int]float64)
+	var candidates []int
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n; i++ {
+			current := community[i]
+			total[current] -= degree[i]
+
+			clear(links)
+			for j, w := range adjacency[i] {
+				if j != i {
+					links[community[j]] += w
+				}
+			}
+
+			// Gain of joining c, up to a factor common to all communities. The
+			// node stays on ties, else the lowest community wins.
+			candidates = candidates[:0]
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			sort.Ints(candidates)
+
+			best, bestGain := current, links[current]-total[current]*degree[i]/weight
+			for _, c := range candidates {
+				if gain := links[c] - total[c]*degree[i]/weight; gain > bestGain+louvainEpsilon {
+					best, bestGain = c, gain
+				}
+			}
+
+			total[best] += degree[i]
+			if best != current {
+				community[i] = best
+				improved, moved = true, true
+			}
+		}
+	}
+
+	return renumber(community), moved
+}
+
+// aggregate builds the graph whose nodes are the communities of the level.
+func aggregate(adjacency []map[int]float64, community []int) []map[int]float64 {
+	size := 0
+	for _, c := range community {
+		size = max(size, c+1)
+	}
+	next := make([]map[int]float64, size)
+	for i := range next {
+		next[i] = make(map[int]float64)
+	}
+	for i, row := range adjacency {
+		for j, w := range row {
+			next[community[i]][community[j]] += w
+		}
+	}
+	return next
+}
+
+// renumber maps community labels to 0..k-1 in order of first appearance.
+func renumber(community []int) []int {
+	ids := make(map[int]int)
+	result := make([]int, len(community))
+	for i, c := range community {
+		id, ok := ids[c]
+		if !ok {
+			id = len(ids)
+			ids[c] = id
+		}
+		result[i] = id
+	}
+	return result
+}
+
+func newPartition(neighbours [][]int, membership []int) *Partition {
+	// Group the nodes and count their links within their community
+	groups := make(map[int][]int)
+	internal := make([]int, len(membership))
+	inside := make(map[int]float64)
+	total := make(map[int]float64)
+	weight := 0.0
+	for i, ns := range neighbours {
+		c := membership[i]
+		groups[c] = append(groups[c], i)
+		total[c] += float64(len(ns))
+		weight += float64(len(ns))
+		for _, j := range ns {
+			if membership[j] == c {
+				internal[i]++
+				inside[c]++
+			}
+		}
+	}
+
+	communities := make([][]int, 0, len(groups))
+	for _, members := range groups {
+		sort.Slice(members, func(a, b int) bool {
+			if internal[members[a]] != internal[members[b]] {
+				return internal[members[a]] > internal[members[b]]
+			}
+			return members[a] < members[b]
+		})
+		communities = append(communities, members)
+	}
+	sort.Slice(communities, func(a, b int) bool {
+		if len(communities[a]) != len(communities[b]) {
+			return len(communities[a]) > len(communities[b])
+		}
+		return smallest(communities[a]) < smallest(communities[b])
+	})
+
+	p := &Partition{Membership: make([]int, len(membership)), Communities: communities}
+	for id, members := range communities {
+		for _, i := range members {
+			p.Membership[i] = id
+		}
+	}
+
+	if weight > 0 {
+		for c := range groups {
+			p.Modularity += inside[c]/weight - (total[c]/weight)*(total[c]/weight)
+		}
+	}
+	return p
+}
+
+func smallest(values []int) int {
+	lowest := values[0]
+	for _, v := range values[1:] {
+		lowest = min(lowest, v)
+	}
+	return lowest
+}
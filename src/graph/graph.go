@@ -0,0 +1,290 @@
+package graph
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// Attribute value types, named after their GraphML counterparts.
+const (
+	TypeString  = "string"
+	TypeInt     = "int"
+	TypeLong    = "long"
+	TypeDouble  = "double"
+	TypeBoolean = "boolean"
+)
+
+// Node is a document of the OSINT graph: an event or a related entity.
+type Node struct {
+	// ID is the document handle, such as events/123
+	ID string
+	// Collection is the collection of the document, which gives its type
+	Collection string
+	Label      string
+	// Attributes holds the scalar fields of the document; nested objects are
+	// flattened with underscores, except that location coordinates are kept
+	// as latitude and longitude
+	Attributes map[string]interface{}
+}
+
+// Sensitivity returns the sensitivity of the document, public when unset.
+func (n Node) Sensitivity() model.Sensitivity {
+	name, _ := n.Attributes["sensitivity"].(string)
+	return model.Sensitivity(model.Sensitivity_value[name])
+}
+
+// Edge is a relation between two nodes.
+type Edge struct {
+	ID         string
+	From       string
+	To         string
+	Name       string
+	Confidence int32
+	Attributes map[string]interface{}
+}
+
+// Graph is a directed subgraph of the OSINT graph. Edges only connect nodes of
+// the graph.
+type Graph struct {
+	Nodes []*Node
+	Edges []*Edge
+
+	index map[string]int
+}
+
+// New builds a graph from raw vertex documents and the relations between
+// them. Duplicate vertices and relations are dropped, as are relations whose
+// ends are missing.
+func New(vertices []map[string]interface{}, relations []*model.Relation) *Graph {
+	g := &Graph{index: make(map[string]int)}
+	for _, vertex := range vertices {
+		id, _ := vertex["_id"].(string)
+		if id == "" {
+			continue
+		}
+		if _, ok := g.index[id]; ok {
+			continue
+		}
+		g.index[id] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, newNode(id, vertex))
+	}
+
+	seen := make(map[string]bool)
+	for _, relation := range relations {
+		if relation == nil || seen[relation.GetId()] {
+			continue
+		}
+		if _, ok := g.index[relation.GetFrom()]; !ok {
+			continue
+		}
+		if _, ok := g.index[relation.GetTo()]; !ok {
+			continue
+		}
+		seen[relation.GetId()] = true
+		g.Edges = append(g.Edges, newEdge(relation))
+	}
+	return g
+}
+
+// Node returns the node with the given document handle, or nil.
+func (g *Graph) Node(id string) *Node {
+	i, ok := g.index[id]
+	if !ok {
+		return nil
+	}
+	return g.Nodes[i]
+}
+
+// Location returns the coordinates of the node, if it has any.
+func (n *Node) Location() (latitude, longitude float64, ok bool) {
+	latitude, okLat := n.Attributes["latitude"].(float64)
+	longitude, okLon := n.Attributes["longitude"].(float64)
+	return latitude, longitude, okLat && okLon
+}
+
+// Time returns when the node happened or was created, in Unix seconds, or
+// zero when unknown.
+func (n *Node) Time() int64 {
+	for _, key := range []string{"happened_at", "created_at", "founded_at", "discovered_at"} {
+		if v, ok := n.Attributes[key].(float64); ok && v > 0 {
+			return int64(v)
+		}
+	}
+	return 0
+}
+
+func newNode(id string, vertex map[string]interface{}) *Node {
+	collection, _, _ := strings.Cut(id, "/")
+	node := &Node{
+		ID:         id,
+		Collection: collection,
+		Attributes: make(map[string]interface{}),
+	}
+
+	for key, value := range vertex {
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		if key == "location" {
+			if location, ok := value.(map[string]interface{}); ok {
+				for k, v := range location {
+					name := "location_" + k
+					if k == "latitude" || k == "longitude" {
+						name = k
+					}
+					flatten(node.Attributes, name, v)
+				}
+				continue
+			}
+		}
+		flatten(node.Attributes, key, value)
+	}
+
+	// Sensitivity is stored as its enum number
+	if v, ok := node.Attributes["sensitivity"].(float64); ok {
+		node.Attributes["sensitivity"] = model.Sensitivity(v).String()
+	}
+
+	for _, key := range []string{"title", "name", "url"} {
+		if label, ok := node.Attributes[key].(string); ok && label != "" {
+			node.Label = label
+			break
+		}
+	}
+	if node.Label == "" {
+		node.Label = id
+	}
+	return node
+}
+
+func newEdge(relation *model.Relation) *Edge {
+	edge := &Edge{
+		ID:         relation.GetId(),
+		From:       relation.GetFrom(),
+		To:         relation.GetTo(),
+		Name:       relation.GetName(),
+		Confidence: relation.GetConfidence(),
+		Attributes: make(map[string]interface{}),
+	}
+	if relation.GetCreatedAt() != 0 {
+		edge.Attributes["created_at"] = float64(relation.GetCreatedAt())
+	}
+	if relation.GetUpdatedAt() != 0 {
+		edge.Attributes["updated_at"] = float64(relation.GetUpdatedAt())
+	}
+
+	for key, value := range relation.GetAttributes() {
+		switch value.GetValue().(type) {
+		case *model.RelationValue_StringValue:
+			edge.Attributes[key] = value.GetStringValue()
+		case *model.RelationValue_IntValue:
+			edge.Attributes[key] = float64(value.GetIntValue())
+		case *model.RelationValue_DoubleValue:
+			edge.Attributes[key] = value.GetDoubleValue()
+		case *model.RelationValue_FloatValue:
+			edge.Attributes[key] = float64(value.GetFloatValue())
+		case *model.RelationValue_BoolValue:
+			edge.Attributes[key] = value.GetBoolValue()
+		}
+	}
+	return edge
+}
+
+// flatten stores scalar values under name, joins lists of scalars with
+// semicolons and recurses into objects.
+func flatten(attributes map[string]interface{}, name string, value interface{}) {
+	switch v := value.(type) {
+	case string, float64, bool:
+		attributes[name] = v
+	case map[string]interface{}:
+		for key, nested := range v {
+			flatten(attributes, name+"_"+key, nested)
+		}
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				items = append(items, s)
+			}
+		}
+		if len(items) > 0 {
+			attributes[name] = strings.Join(items, ";")
+		}
+	}
+}
+
+// AttributeKey is an attribute found on nodes or edges, with the type that
+// fits all its values.
+type AttributeKey struct {
+	Name string
+	Type string
+}
+
+// AttributeKeys returns the attributes of the given attribute maps sorted by
+// name. Numbers are longs unless some value has a fraction.
+func AttributeKeys(attributes []map[string]interface{}) []AttributeKey {
+	types := make(map[string]string)
+	for _, attrs := range attributes {
+		for name, value := range attrs {
+			types[name] = mergeType(types[name], valueType(value))
+		}
+	}
+
+	// Coordinates are doubles even when they happen to be whole numbers
+	for _, name := range []string{"latitude", "longitude"} {
+		if types[name] == TypeLong {
+			types[name] = TypeDouble
+		}
+	}
+
+	keys := make([]AttributeKey, 0, len(types))
+	for name, t := range types {
+		keys = append(keys, AttributeKey{Name: name, Type: t})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys
+}
+
+// NodeAttributes returns the attribute maps of all nodes.
+func (g *Graph) NodeAttributes() []map[string]interface{} {
+	attributes := make([]map[string]interface{}, len(g.Nodes))
+	for i, node := range g.Nodes {
+		attributes[i] = node.Attributes
+	}
+	return attributes
+}
+
+// EdgeAttributes returns the attribute maps of all edges.
+func (g *Graph) EdgeAttributes() []map[string]interface{} {
+	attributes := make([]map[string]interface{}, len(g.Edges))
+	for i, edge := range g.Edges {
+		attributes[i] = edge.Attributes
+	}
+	return attributes
+}
+
+func valueType(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return TypeBoolean
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return TypeLong
+		}
+		return TypeDouble
+	}
+	return TypeString
+}
+
+func mergeType(a, b string) string {
+	switch {
+	case a == "" || a == b:
+		return b
+	case (a == TypeLong && b == TypeDouble) || (a == TypeDouble && b == TypeLong):
+		return TypeDouble
+	}
+	return TypeString
+}
@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+func TestNew(t *testing.T) {
+	vertices := []map[string]interface{}{
+		{
+			"_id": "events/1", "_key": "1", "title": "Strike", "happened_at": float64(1706745600),
+			"sensitivity": float64(model.Sensitivity_SENSITIVITY_CONFIDENTIAL),
+			"location":    map[string]interface{}{"latitude": 50.0, "longitude": 36.25, "country_code": "UA"},
+			"tags":        []interface{}{"artillery", "night"},
+		},
+		{"_id": "persons/1", "name": "Analyst"},
+		{"_id": "events/1", "title": "Duplicate"},
+	}
+	relations := []*model.Relation{
+		{Id: "relations/1", From: "persons/1", To: "events/1", Name: "witnessed", Confidence: 80},
+		{Id: "relations/1", From: "persons/1", To: "events/1"},
+		{Id: "relations/2", From: "persons/1", To: "organizations/9"},
+	}
+
+	g := New(vertices, relations)
+	if len(g.Nodes) != 2 || len(g.Edges) != 1 {
+		t.Fatalf("Expected 2 nodes and 1 edge, got %d and %d", len(g.Nodes), len(g.Edges))
+	}
+
+	event := g.Node("events/1")
+	if event.Label != "Strike" || event.Collection != "events" || event.Time() != 1706745600 {
+		t.Errorf("Unexpected event node: %+v", event)
+	}
+	if lat, lon, ok := event.Location(); !ok || lat != 50 || lon != 36.25 {
+		t.Errorf("Expected event location 50,36.25, got %v,%v", lat, lon)
+	}
+	if event.Attributes["location_country_code"] != "UA" || event.Attributes["tags"] != "artillery;night" {
+		t.Errorf("Unexpected flattened attributes: %v", event.Attributes)
+	}
+	if event.Attributes["sensitivity"] != "SENSITIVITY_CONFIDENTIAL" {
+		t.Errorf("Expected sensitivity name, got %v", event.Attributes["sensitivity"])
+	}
+	if _, ok := event.Attributes["_key"]; ok {
+		t.Error("Expected system fields to be dropped")
+	}
+
+	keys := AttributeKeys(g.NodeAttributes())
+	types := make(map[string]string)
+	for _, key := range keys {
+		types[key.Name] = key.Type
+	}
+	if types["latitude"] != TypeDouble || types["happened_at"] != TypeLong || types["name"] != TypeString {
+		t.Errorf("Unexpected attribute types: %v", types)
+	}
+}
+
+func TestCentrality(t *testing.T) {
+	// A star around persons/hub, with a tail hanging off events/3
+	vertices := []map[string]interface{}{
+		{"_id": "persons/hub"}, {"_id": "events/1"}, {"_id": "events/2"}, {"_id": "events/3"}, {"_id": "persons/tail"},
+	}
+	relations := []*model.Relation{
+		{Id: "relations/1", From: "persons/hub", To: "events/1"},
+		{Id: "relations/2", From: "persons/hub", To: "events/2"},
+		{Id: "relations/3", From: "events/3", To: "persons/hub"},
+		{Id: "relations/4", From: "persons/tail", To: "events/3"},
+	}
+
+	g := New(vertices, relations)
+	c := g.Centrality()
+
+	if c.Degree[0] != 3 || c.Degree[3] != 2 || c.Degree[4] != 1 {
+		t.Errorf("Unexpected degrees: %v", c.Degree)
+	}
+
+	// The hub lies on 5 of the 6 paths between the other nodes
+	if math.Abs(c.Betweenness[0]-5.0/6) > 1e-9 {
+		t.Errorf("Expected hub betweenness 5/6, got %v", c.Betweenness[0])
+	}
+	if c.Betweenness[1] != 0 || c.Betweenness[3] <= 0 {
+		t.Errorf("Unexpected betweenness: %v", c.Betweenness)
+	}
+
+	total := 0.0
+	for i, rank := range c.PageRank {
+		total += rank
+		if i > 0 && rank >= c.PageRank[0] {
+			t.Errorf("Expected the hub to have the highest PageRank, got %v", c.PageRank)
+		}
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("Expected PageRank to sum to 1, got %v", total)
+	}
+}
+
+func TestCommunities(t *testing.T) {
+	// Two triangles joined by a single relation, plus an isolated node
+	var vertices []map[string]interface{}
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		vertices = append(vertices, map[string]interface{}{"_id": "persons/" + id})
+	}
+	var relations []*model.Relation
+	for i, pair := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"d", "e"}, {"e", "f"}, {"f", "d"}, {"c", "d"}} {
+		relations = append(relations, &model.Relation{
+			Id: "relations/" + string(rune('0'+i)), From: "persons/" + pair[0], To: "persons/" + pair[1],
+		})
+	}
+
+	p := New(vertices, relations).Communities()
+	if len(p.Communities) != 3 {
+		t.Fatalf("Expected 3 communities, got %v", p.Communities)
+	}
+	m := p.Membership
+	if m[0] != m[1] || m[1] != m[2] || m[3] != m[4] || m[4] != m[5] || m[0] == m[3] {
+		t.Errorf("Expected one community per triangle, got %v", m)
+	}
+	if m[6] != 2 || len(p.Communities[2]) != 1 {
+		t.Errorf("Expected the isolated node to be the smallest community, got %v", m)
+	}
+	if first := p.Communities[0]; first[0] != 0 || first[1] != 1 || first[2] != 2 {
+		t.Errorf("Expected members with equal links in node order, got %v", first)
+	}
+
+	// 2 * (6/14 - (7/14)^2)
+	if math.Abs(p.Modularity-5.0/14) > 1e-9 {
+		t.Errorf("Expected modularity 5/14, got %v", p.Modularity)
+	}
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/arangodb/go-driver"
+)
+
+// Connectivity checks that the ArangoDB server answers.
+func Connectivity(client driver.Client) Check {
+	return func(ctx context.Context) error {
+		_, err := client.Version(ctx)
+		return err
+	}
+}
+
+// CollectionExists checks that the collection exists in db.
+func CollectionExists(db driver.Database, name string) Check {
+	return func(ctx context.Context) error {
+		ok, err := db.CollectionExists(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("collection %s does not exist", name)
+		}
+		return nil
+	}
+}
+
+// IndexesExist checks that the collection has an index on each of the given
+// field lists, in order.
+func IndexesExist(db driver.Database, name string, indexes [][]string) Check {
+	return func(ctx context.Context) error {
+		collection, err := db.Collection(ctx, name)
+		if err != nil {
+			return err
+		}
+		existing, err := collection.Indexes(ctx)
+		if err != nil {
+			return err
+		}
+
+		var missing []string
+		for _, fields := range indexes {
+			found := slices.ContainsFunc(existing, func(index driver.Index) bool {
+				return slices.Equal(index.Fields(), fields)
+			})
+			if !found {
+				missing = append(missing, strings.Join(fields, ","))
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("collection %s is missing indexes on %s", name, strings.Join(missing, "; "))
+		}
+		return nil
+	}
+}
+
+// GraphExists checks that the named graph exists in db.
+func GraphExists(db driver.Database, name string) Check {
+	return func(ctx context.Context) error {
+		ok, err := db.GraphExists(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("graph %s does not exist", name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Status values of a check and of the whole report.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// checkTimeout bounds every check so that one hanging dependency cannot hold
+// up the probe.
+const checkTimeout = 3 * time.Second
+
+// watchInterval is how often Watch refreshes the gRPC health status.
+const watchInterval = 10 * time.Second
+
+// Check verifies one dependency, returning nil when it is usable.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of one check.
+type Result struct {
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// Report is the outcome of all checks, keyed by check name. The status is ok
+// only when every check passed.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Checker runs the readiness checks of the service.
+type Checker struct {
+	mu     sync.Mutex
+	checks map[string]Check
+}
+
+// NewChecker creates a checker without checks, which is always ready.
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+// Add registers a check under name, replacing any check of the same name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Run runs all checks concurrently.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.Lock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.Unlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := run(ctx, check)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := Result{
+		Status:     StatusOK,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Live answers the liveness probe. It checks nothing beyond the process
+// serving HTTP, so that a database outage does not get the pod restarted.
+func Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": StatusOK})
+}
+
+// Ready answers the readiness probe with the report of all checks, with 503
+// when any of them failed.
+func (c *Checker) Ready(ctx *gin.Context) {
+	report := c.Run(ctx.Request.Context())
+	code := http.StatusOK
+	if report.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	ctx.JSON(code, report)
+}
+
+// Watch runs the checks periodically until ctx is done and publishes the
+// outcome on server for the overall status and the given services.
+func (c *Checker) Watch(ctx context.Context, server *health.Server, services ...string) {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		report := c.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if report.Status != StatusOK {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if servingStatus != last {
+			logrus.WithFields(logrus.Fields{
+				"status": servingStatus.String(),
+				"checks": report.Checks,
+			}).Info("readiness changed")
+			last = servingStatus
+		}
+		server.SetServingStatus("", servingStatus)
+		for _, service := range services {
+			server.SetServingStatus(service, servingStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
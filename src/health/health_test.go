@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestChecker(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(checker *Checker) (*httptest.ResponseRecorder, Report) {
+		r := gin.New()
+		r.GET("/readyz", checker.Ready)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		var report Report
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("Expected a JSON report, got %s", rec.Body.String())
+		}
+		return rec, report
+	}
+
+	t.Run("Ready", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Add("arangodb", func(ctx context.Context) error { return nil })
+
+		rec, report := serve(checker)
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected 200, got %d", rec.Code)
+		}
+		if report.Status != StatusOK || report.Checks["arangodb"].Status != StatusOK {
+			t.Errorf("Expected every check to pass, got %+v", report)
+		}
+	})
+
+	t.Run("Not Ready", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Add("arangodb", func(ctx context.Context) error { return nil })
+		checker.Add("osint_graph", func(ctx context.Context) error { return errors.New("graph osint does not exist") })
+
+		rec, report := serve(checker)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected 503, got %d", rec.Code)
+		}
+		if report.Status != StatusFail {
+			t.Errorf("Expected the report to fail, got %s", report.Status)
+		}
+		if report.Checks["arangodb"].Status != StatusOK {
+			t.Errorf("Expected arangodb to pass, got %+v", report.Checks["arangodb"])
+		}
+		if got := report.Checks["osint_graph"]; got.Status != StatusFail || got.Error != "graph osint does not exist" {
+			t.Errorf("Expected osint_graph to fail with its error, got %+v", got)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Add("arangodb", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		report := checker.Run(ctx)
+		if report.Checks["arangodb"].Status != StatusFail {
+			t.Errorf("Expected a cancelled check to fail, got %+v", report.Checks["arangodb"])
+		}
+	})
+}
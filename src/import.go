@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/omnsight/geovision/src/ingest"
+	"github.com/omnsight/geovision/src/services"
+	"github.com/omnsight/omniscent-library/src/clients"
+	"github.com/sirupsen/logrus"
+)
+
+// runImport imports ACLED or GDELT files straight into the events collection:
+//
+//	geovision import -format acled [-batch-size 500] [-dry-run] FILE...
+func runImport(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", "input format: acled or gdelt")
+	batchSize := flags.Int("batch-size", ingest.DefaultBatchSize, "number of events inserted per batch")
+	dryRun := flags.Bool("dry-run", false, "parse and de-duplicate without inserting anything")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: geovision import -format acled|gdelt [-batch-size N] [-dry-run] FILE...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format == "" || flags.NArg() == 0 || *batchSize <= 0 || *batchSize > ingest.MaxBatchSize {
+		flags.Usage()
+		return 2
+	}
+
+	client, err := clients.NewArangoDBClient()
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to establish ArangoDB client")
+		return 1
+	}
+	eventService, err := services.NewGeoService(client)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to create EventService")
+		return 1
+	}
+
+	ctx := context.Background()
+	opts := ingest.Options{BatchSize: *batchSize, DryRun: *dryRun}
+	failed := false
+
+	for _, path := range flags.Args() {
+		logger := logrus.WithField("file", path)
+
+		file, err := os.Open(path)
+		if err != nil {
+			logger.WithError(err).Error("failed to open import file")
+			failed = true
+			continue
+		}
+
+		reader, err := ingest.NewReader(*format, file)
+		if err != nil {
+			file.Close()
+			logger.WithError(err).Error("invalid import file")
+			failed = true
+			continue
+		}
+
+		progress, err := ingest.Import(ctx, eventService, reader, opts, func(p ingest.Progress) error {
+			for _, rowErr := range p.Errors {
+				logger.WithField("line", rowErr.Line).Warn(rowErr.Message)
+			}
+			logger.Infof("%d rows read, %d imported, %d duplicates, %d failed", p.Rows, p.Imported, p.Duplicates, p.Failed)
+			return nil
+		})
+		file.Close()
+		if err != nil {
+			logger.WithError(err).Error("import failed")
+			failed = true
+			continue
+		}
+
+		verb := "Imported"
+		if *dryRun {
+			verb = "Would import"
+		}
+		logger.Infof("%s %d of %d rows (%d duplicates, %d failed)", verb, progress.Imported, progress.Rows, progress.Duplicates, progress.Failed)
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
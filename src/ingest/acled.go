@@ -0,0 +1,123 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// ACLEDDataset prefixes the keys of events imported from ACLED.
+const ACLEDDataset = "acled"
+
+// acledColumns are the ACLED export columns the importer relies on.
+var acledColumns = []string{"event_id_cnty", "event_date", "event_type", "latitude", "longitude"}
+
+// acledReader reads the CSV export of the ACLED data portal, which has a
+// header row naming every column.
+type acledReader struct {
+	csv     *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+// NewACLEDReader reads an ACLED CSV export.
+func NewACLEDReader(r io.Reader) (Reader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ACLED header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range acledColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("ACLED header is missing column %q", name)
+		}
+	}
+
+	return &acledReader{csv: reader, columns: columns}, nil
+}
+
+func (a *acledReader) Line() int {
+	return a.line
+}
+
+func (a *acledReader) Read() (*model.Event, error) {
+	record, err := a.csv.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	line, err := recordLine(a.csv, err)
+	a.line = line
+	if err != nil {
+		return nil, err
+	}
+
+	field := func(name string) string {
+		i, ok := a.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	id := field("event_id_cnty")
+	if id == "" {
+		return nil, rowErrorf(line, "missing event_id_cnty")
+	}
+	key, err := sourceKey(line, ACLEDDataset, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// ACLED dates are days, written as 2024-01-31 or 31 January 2024
+	// depending on the export
+	var happened time.Time
+	for _, layout := range []string{"2006-01-02", "02 January 2006", "2 January 2006"} {
+		if happened, err = time.Parse(layout, field("event_date")); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, rowErrorf(line, "invalid event_date %q", field("event_date"))
+	}
+
+	lat, lon, err := parseCoordinates(line, field("latitude"), field("longitude"))
+	if err != nil {
+		return nil, err
+	}
+
+	kind := field("sub_event_type")
+	if kind == "" {
+		kind = field("event_type")
+	}
+	title := kind
+	if place := field("location"); place != "" {
+		title = fmt.Sprintf("%s in %s", kind, place)
+	}
+
+	return &model.Event{
+		Key:         key,
+		Title:       title,
+		Description: field("notes"),
+		HappenedAt:  happened.Unix(),
+		Location: &model.LocationData{
+			Latitude:              lat,
+			Longitude:             lon,
+			AdministrativeArea:    field("admin1"),
+			SubAdministrativeArea: field("admin2"),
+			Locality:              field("location"),
+		},
+		Tags: appendTags([]string{ACLEDDataset}, field("disorder_type"), field("event_type"), field("sub_event_type")),
+	}, nil
+}
@@ -0,0 +1,150 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// GDELTDataset prefixes the keys of events imported from GDELT.
+const GDELTDataset = "gdelt"
+
+// GDELT 2.0 event table columns, see
+// http://data.gdeltproject.org/documentation/GDELT-Event_Codebook-V2.0.pdf
+const (
+	gdeltGlobalEventID = 0
+	gdeltDay           = 1
+	gdeltActor1Name    = 6
+	gdeltActor2Name    = 16
+	gdeltEventCode     = 26
+	gdeltEventRootCode = 28
+	gdeltQuadClass     = 29
+	gdeltActionGeoName = 52
+	gdeltActionGeoLat  = 56
+	gdeltActionGeoLong = 57
+	gdeltSourceURL     = 60
+	gdeltColumns       = 61
+)
+
+// cameoRoots names the top level CAMEO event codes.
+var cameoRoots = map[string]string{
+	"01": "Make public statement",
+	"02": "Appeal",
+	"03": "Express intent to cooperate",
+	"04": "Consult",
+	"05": "Engage in diplomatic cooperation",
+	"06": "Engage in material cooperation",
+	"07": "Provide aid",
+	"08": "Yield",
+	"09": "Investigate",
+	"10": "Demand",
+	"11": "Disapprove",
+	"12": "Reject",
+	"13": "Threaten",
+	"14": "Protest",
+	"15": "Exhibit force posture",
+	"16": "Reduce relations",
+	"17": "Coerce",
+	"18": "Assault",
+	"19": "Fight",
+	"20": "Use unconventional mass violence",
+}
+
+// gdeltQuadClasses names the GDELT quad classes.
+var gdeltQuadClasses = map[string]string{
+	"1": "verbal cooperation",
+	"2": "material cooperation",
+	"3": "verbal conflict",
+	"4": "material conflict",
+}
+
+// gdeltReader reads GDELT 2.0 event files, which are tab separated and have
+// no header row.
+type gdeltReader struct {
+	csv  *csv.Reader
+	line int
+}
+
+// NewGDELTReader reads a GDELT 2.0 events export.
+func NewGDELTReader(r io.Reader) Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = '\t'
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	return &gdeltReader{csv: reader}
+}
+
+func (g *gdeltReader) Line() int {
+	return g.line
+}
+
+func (g *gdeltReader) Read() (*model.Event, error) {
+	record, err := g.csv.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	line, err := recordLine(g.csv, err)
+	g.line = line
+	if err != nil {
+		return nil, err
+	}
+	if len(record) < gdeltColumns {
+		return nil, rowErrorf(line, "expected %d columns, got %d", gdeltColumns, len(record))
+	}
+
+	id := strings.TrimSpace(record[gdeltGlobalEventID])
+	if id == "" {
+		return nil, rowErrorf(line, "missing GLOBALEVENTID")
+	}
+	key, err := sourceKey(line, GDELTDataset, id)
+	if err != nil {
+		return nil, err
+	}
+
+	happened, err := time.Parse("20060102", strings.TrimSpace(record[gdeltDay]))
+	if err != nil {
+		return nil, rowErrorf(line, "invalid day %q", record[gdeltDay])
+	}
+
+	// Events are placed where the action happened
+	if record[gdeltActionGeoLat] == "" || record[gdeltActionGeoLong] == "" {
+		return nil, rowErrorf(line, "event has no action location")
+	}
+	lat, lon, err := parseCoordinates(line, record[gdeltActionGeoLat], record[gdeltActionGeoLong])
+	if err != nil {
+		return nil, err
+	}
+
+	action, ok := cameoRoots[record[gdeltEventRootCode]]
+	if !ok {
+		action = "CAMEO " + record[gdeltEventCode]
+	}
+	var actors []string
+	for _, actor := range []string{record[gdeltActor1Name], record[gdeltActor2Name]} {
+		if actor = strings.TrimSpace(actor); actor != "" {
+			actors = append(actors, actor)
+		}
+	}
+	title := action
+	if len(actors) > 0 {
+		title = fmt.Sprintf("%s: %s", action, strings.Join(actors, " / "))
+	}
+
+	return &model.Event{
+		Key:         key,
+		Title:       title,
+		Description: strings.TrimSpace(record[gdeltSourceURL]),
+		HappenedAt:  happened.Unix(),
+		Location: &model.LocationData{
+			Latitude:  lat,
+			Longitude: lon,
+			Address:   strings.TrimSpace(record[gdeltActionGeoName]),
+		},
+		Tags: appendTags([]string{GDELTDataset}, action, gdeltQuadClasses[record[gdeltQuadClass]]),
+	}, nil
+}
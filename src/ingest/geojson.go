@@ -0,0 +1,218 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// GeoJSONDataset prefixes the keys of events imported from GeoJSON.
+const GeoJSONDataset = "geojson"
+
+// PropertyMapping names the feature properties that fill each event field.
+// Key is optional; without it the feature id, if any, identifies the event.
+type PropertyMapping struct {
+	Key         string
+	Title       string
+	Description string
+	HappenedAt  string
+	Tags        string
+	Sensitivity string
+}
+
+// withDefaults fills unset property names with the event field names.
+func (m PropertyMapping) withDefaults() PropertyMapping {
+	if m.Title == "" {
+		m.Title = "title"
+	}
+	if m.Description == "" {
+		m.Description = "description"
+	}
+	if m.HappenedAt == "" {
+		m.HappenedAt = "happened_at"
+	}
+	if m.Tags == "" {
+		m.Tags = "tags"
+	}
+	if m.Sensitivity == "" {
+		m.Sensitivity = "sensitivity"
+	}
+	return m
+}
+
+// geojsonReader turns the Point features of a FeatureCollection into events.
+// Row errors carry the index of the feature instead of a line number.
+type geojsonReader struct {
+	features []geo.Feature
+	mapping  PropertyMapping
+	next     int
+}
+
+// NewGeoJSONReader reads a GeoJSON FeatureCollection.
+func NewGeoJSONReader(data []byte, mapping PropertyMapping) (Reader, error) {
+	var collection geo.FeatureCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %v", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("expected a FeatureCollection, got %q", collection.Type)
+	}
+	return &geojsonReader{features: collection.Features, mapping: mapping.withDefaults()}, nil
+}
+
+func (g *geojsonReader) Line() int {
+	return g.next - 1
+}
+
+func (g *geojsonReader) Read() (*model.Event, error) {
+	if g.next >= len(g.features) {
+		return nil, io.EOF
+	}
+	index := g.next
+	feature := g.features[index]
+	g.next++
+
+	p, err := feature.Geometry.Point()
+	if err != nil {
+		return nil, rowErrorf(index, "%v", err)
+	}
+
+	props := feature.Properties
+	title, ok := props[g.mapping.Title].(string)
+	if !ok || strings.TrimSpace(title) == "" {
+		return nil, rowErrorf(index, "missing %s property", g.mapping.Title)
+	}
+
+	description, ok := props[g.mapping.Description].(string)
+	if !ok && props[g.mapping.Description] != nil {
+		return nil, rowErrorf(index, "%s must be a string", g.mapping.Description)
+	}
+
+	happened, err := parseTime(props[g.mapping.HappenedAt])
+	if err != nil {
+		return nil, rowErrorf(index, "invalid %s: %v", g.mapping.HappenedAt, err)
+	}
+
+	tags, err := parseTags(props[g.mapping.Tags])
+	if err != nil {
+		return nil, rowErrorf(index, "invalid %s: %v", g.mapping.Tags, err)
+	}
+
+	sensitivity, err := parseSensitivity(props[g.mapping.Sensitivity])
+	if err != nil {
+		return nil, rowErrorf(index, "invalid %s: %v", g.mapping.Sensitivity, err)
+	}
+
+	event := &model.Event{
+		Title:       strings.TrimSpace(title),
+		Description: description,
+		HappenedAt:  happened,
+		Sensitivity: sensitivity,
+		Location: &model.LocationData{
+			Latitude:  float32(p.Latitude),
+			Longitude: float32(p.Longitude),
+		},
+		Tags: tags,
+	}
+
+	id := feature.ID
+	if g.mapping.Key != "" {
+		id = props[g.mapping.Key]
+	}
+	switch v := id.(type) {
+	case string:
+		if v != "" {
+			if event.Key, err = sourceKey(index, GeoJSONDataset, v); err != nil {
+				return nil, err
+			}
+		}
+	case float64:
+		event.Key = SourceKey(GeoJSONDataset, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+
+	return event, nil
+}
+
+// parseTime accepts Unix seconds, RFC 3339 timestamps and dates.
+func parseTime(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case float64:
+		if v <= 0 || v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected positive Unix seconds, got %v", v)
+		}
+		return int64(v), nil
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t.Unix(), nil
+			}
+		}
+		return 0, fmt.Errorf("unrecognized time %q", v)
+	case nil:
+		return 0, fmt.Errorf("property is missing")
+	}
+	return 0, fmt.Errorf("expected a number or a string")
+}
+
+// parseTags accepts an array of strings or a comma separated string. Tags
+// are kept as written, minus surrounding spaces and duplicates.
+func parseTags(value interface{}) ([]string, error) {
+	var values []string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		values = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			tag, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected strings, got %v", item)
+			}
+			values = append(values, tag)
+		}
+	default:
+		return nil, fmt.Errorf("expected an array or a string")
+	}
+
+	var tags []string
+	for _, tag := range values {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// parseSensitivity accepts enum names with or without the SENSITIVITY_
+// prefix, in any case, and enum numbers. Missing values are public.
+func parseSensitivity(value interface{}) (model.Sensitivity, error) {
+	switch v := value.(type) {
+	case nil:
+		return model.Sensitivity_SENSITIVITY_PUBLIC_UNSPECIFIED, nil
+	case string:
+		name := strings.ToUpper(strings.TrimSpace(v))
+		if name == "" || name == "PUBLIC" {
+			return model.Sensitivity_SENSITIVITY_PUBLIC_UNSPECIFIED, nil
+		}
+		if !strings.HasPrefix(name, "SENSITIVITY_") {
+			name = "SENSITIVITY_" + name
+		}
+		if number, ok := model.Sensitivity_value[name]; ok {
+			return model.Sensitivity(number), nil
+		}
+	case float64:
+		if _, ok := model.Sensitivity_name[int32(v)]; ok && v == math.Trunc(v) {
+			return model.Sensitivity(v), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown sensitivity %v", value)
+}
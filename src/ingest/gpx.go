@@ -0,0 +1,162 @@
+package ingest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// GPX is a decoded GPX 1.0 or 1.1 document.
+type GPX struct {
+	Metadata struct {
+		Name string    `xml:"name"`
+		Time time.Time `xml:"time"`
+	} `xml:"metadata"`
+	Waypoints []GPXPoint `xml:"wpt"`
+	Tracks    []GPXTrack `xml:"trk"`
+}
+
+// GPXTrack is a track made of one or more continuous segments.
+type GPXTrack struct {
+	Name     string `xml:"name"`
+	Segments []struct {
+		Points []GPXPoint `xml:"trkpt"`
+	} `xml:"trkseg"`
+}
+
+// GPXPoint is a waypoint or a track point.
+type GPXPoint struct {
+	Latitude    float64   `xml:"lat,attr"`
+	Longitude   float64   `xml:"lon,attr"`
+	Elevation   float64   `xml:"ele"`
+	Time        time.Time `xml:"time"`
+	Name        string    `xml:"name"`
+	Description string    `xml:"desc"`
+}
+
+// Point returns the coordinate of the point.
+func (p GPXPoint) Point() geo.Point {
+	return geo.Point{Latitude: p.Latitude, Longitude: p.Longitude}
+}
+
+// ParseGPX decodes a GPX document and checks that every coordinate is valid.
+func ParseGPX(data []byte) (*GPX, error) {
+	var doc GPX
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid GPX: %v", err)
+	}
+
+	check := func(p GPXPoint, where string) error {
+		if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
+			return fmt.Errorf("%s has out of range coordinates %g,%g", where, p.Latitude, p.Longitude)
+		}
+		return nil
+	}
+	for i, p := range doc.Waypoints {
+		if err := check(p, fmt.Sprintf("waypoint %d", i)); err != nil {
+			return nil, err
+		}
+	}
+	for t, track := range doc.Tracks {
+		for s, segment := range track.Segments {
+			for i, p := range segment.Points {
+				if err := check(p, fmt.Sprintf("track %d segment %d point %d", t, s, i)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return &doc, nil
+}
+
+// GPXOptions controls how a GPX document becomes events.
+type GPXOptions struct {
+	// Tolerance in meters used to simplify tracks; zero keeps every point
+	Tolerance   float64
+	Tags        []string
+	Sensitivity model.Sensitivity
+}
+
+// GPXEvents are the events built from a GPX document.
+type GPXEvents struct {
+	Waypoints []*model.Event
+	// Paths are the simplified track segments, whose consecutive events
+	// should be linked
+	Paths [][]*model.Event
+	// TrackPoints counts the track points before simplification
+	TrackPoints int
+}
+
+// Events turns waypoints into standalone events and every track segment
+// into a path of events, simplified with the given tolerance. Points without
+// a time take the time of the document, or now.
+func (doc *GPX) Events(opts GPXOptions, now time.Time) *GPXEvents {
+	fallback := now
+	if !doc.Metadata.Time.IsZero() {
+		fallback = doc.Metadata.Time
+	}
+
+	newEvent := func(p GPXPoint, title string, kind string) *model.Event {
+		happened := p.Time
+		if happened.IsZero() {
+			happened = fallback
+		}
+		return &model.Event{
+			Title:       title,
+			Description: p.Description,
+			HappenedAt:  happened.Unix(),
+			Sensitivity: opts.Sensitivity,
+			Location: &model.LocationData{
+				Latitude:  float32(p.Latitude),
+				Longitude: float32(p.Longitude),
+			},
+			Tags: appendTags(append([]string{}, opts.Tags...), "gpx", kind),
+		}
+	}
+
+	result := &GPXEvents{}
+	for i, p := range doc.Waypoints {
+		title := p.Name
+		if title == "" {
+			title = fmt.Sprintf("Waypoint %d", i+1)
+		}
+		result.Waypoints = append(result.Waypoints, newEvent(p, title, "waypoint"))
+	}
+
+	for t, track := range doc.Tracks {
+		name := track.Name
+		if name == "" {
+			name = doc.Metadata.Name
+		}
+		if name == "" {
+			name = fmt.Sprintf("Track %d", t+1)
+		}
+
+		for _, segment := range track.Segments {
+			result.TrackPoints += len(segment.Points)
+
+			points := make([]geo.Point, len(segment.Points))
+			for i, p := range segment.Points {
+				points[i] = p.Point()
+			}
+			kept := geo.Simplify(points, opts.Tolerance)
+
+			path := make([]*model.Event, 0, len(kept))
+			for n, i := range kept {
+				p := segment.Points[i]
+				title := p.Name
+				if title == "" {
+					title = fmt.Sprintf("%s (%d/%d)", name, n+1, len(kept))
+				}
+				path = append(path, newEvent(p, title, "track"))
+			}
+			if len(path) > 0 {
+				result.Paths = append(result.Paths, path)
+			}
+		}
+	}
+	return result
+}
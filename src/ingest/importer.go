@@ -0,0 +1,174 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// DefaultBatchSize is the number of events inserted per round trip.
+const DefaultBatchSize = 500
+
+// MaxBatchSize bounds the batch size callers may ask for.
+const MaxBatchSize = 10000
+
+// Store persists imported events.
+type Store interface {
+	// ExistingKeys returns which of the given document keys already exist.
+	ExistingKeys(ctx context.Context, keys []string) (map[string]bool, error)
+	// InsertEvents stores the events and returns how many were inserted and
+	// how many already existed, along with why each of the others was
+	// rejected, by index in events. err is set when the whole batch failed.
+	InsertEvents(ctx context.Context, events []*model.Event) (inserted, duplicates int, rejected map[int]error, err error)
+}
+
+// Options controls an import.
+type Options struct {
+	BatchSize int
+	// DryRun parses and de-duplicates without inserting anything
+	DryRun bool
+}
+
+// Progress is the running total of an import. Errors only holds the row
+// errors found since the previous report.
+type Progress struct {
+	Rows       int
+	Imported   int
+	Duplicates int
+	Failed     int
+	Errors     []*RowError
+}
+
+// Import reads every event from the reader and inserts those that are not
+// stored yet, in batches. Events with a document key are de-duplicated by
+// it, both within the input and against the store. report is called after
+// every batch with the totals so far.
+func Import(ctx context.Context, store Store, reader Reader, opts Options, report func(Progress) error) (Progress, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+
+	var progress Progress
+	seen := make(map[string]bool)
+	batch := make([]*model.Event, 0, opts.BatchSize)
+	lines := make([]int, 0, opts.BatchSize)
+
+	flush := func() error {
+		if len(batch) > 0 {
+			if err := insertBatch(ctx, store, batch, lines, opts.DryRun, &progress); err != nil {
+				return err
+			}
+			batch, lines = batch[:0], lines[:0]
+		}
+		if report != nil {
+			if err := report(progress); err != nil {
+				return err
+			}
+		}
+		progress.Errors = nil
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+
+		event, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			progress.Rows++
+			progress.Failed++
+			progress.Errors = append(progress.Errors, rowErr)
+			continue
+		}
+		if err != nil {
+			return progress, err
+		}
+
+		progress.Rows++
+		// Events without a key cannot be told apart and get a generated one
+		if key := event.GetKey(); key != "" {
+			if seen[key] {
+				progress.Duplicates++
+				continue
+			}
+			seen[key] = true
+		}
+
+		batch = append(batch, event)
+		lines = append(lines, reader.Line())
+		if len(batch) >= opts.BatchSize {
+			if err := flush(); err != nil {
+				return progress, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return progress, err
+	}
+	return progress, nil
+}
+
+func insertBatch(ctx context.Context, store Store, batch []*model.Event, lines []int, dryRun bool, progress *Progress) error {
+	keys := make([]string, 0, len(batch))
+	for _, event := range batch {
+		if event.GetKey() != "" {
+			keys = append(keys, event.GetKey())
+		}
+	}
+	existing := map[string]bool{}
+	if len(keys) > 0 {
+		var err error
+		if existing, err = store.ExistingKeys(ctx, keys); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().Unix()
+	fresh := make([]*model.Event, 0, len(batch))
+	freshLines := make([]int, 0, len(batch))
+	for i, event := range batch {
+		if event.GetKey() != "" && existing[event.GetKey()] {
+			progress.Duplicates++
+			continue
+		}
+		event.UpdatedAt = now
+		fresh = append(fresh, event)
+		freshLines = append(freshLines, lines[i])
+	}
+
+	if dryRun || len(fresh) == 0 {
+		progress.Imported += len(fresh)
+		return nil
+	}
+
+	// Rows inserted concurrently by someone else surface as duplicates here
+	inserted, duplicates, rejected, err := store.InsertEvents(ctx, fresh)
+	if err != nil {
+		return err
+	}
+	progress.Imported += inserted
+	progress.Duplicates += duplicates
+
+	// Rejected events fail on their own, like rows that could not be parsed
+	indexes := make([]int, 0, len(rejected))
+	for i := range rejected {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	for _, i := range indexes {
+		progress.Failed++
+		progress.Errors = append(progress.Errors, rowErrorf(freshLines[i], "failed to store event: %v", rejected[i]))
+	}
+	return nil
+}
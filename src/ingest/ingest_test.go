@@ -0,0 +1,322 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+const acledSample = `event_id_cnty,event_date,disorder_type,event_type,sub_event_type,admin1,admin2,location,latitude,longitude,notes
+UKR1,2024-02-01,Political violence,Explosions/Remote violence,Shelling/artillery/missile attack,Kharkivska,Kharkivskyi,Kharkiv,49.99,36.23,"Shelling hit
+the city centre."
+UKR2,01 February 2024,Demonstrations,Protests,Peaceful protest,Kyiv,,Kyiv,50.45,30.52,
+UKR3,not a date,Demonstrations,Protests,Peaceful protest,Kyiv,,Kyiv,50.45,30.52,
+UKR1,2024-02-01,Political violence,Explosions/Remote violence,Shelling/artillery/missile attack,Kharkivska,Kharkivskyi,Kharkiv,49.99,36.23,
+`
+
+// gdeltRow builds a GDELT 2.0 row from the columns that matter.
+func gdeltRow(values map[int]string) string {
+	fields := make([]string, gdeltColumns)
+	for i, v := range values {
+		fields[i] = v
+	}
+	return strings.Join(fields, "\t")
+}
+
+func readAll(t *testing.T, reader Reader) ([]*model.Event, []*RowError) {
+	var events []*model.Event
+	var rowErrs []*RowError
+	for {
+		event, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return events, rowErrs
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrs = append(rowErrs, rowErr)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		events = append(events, event)
+	}
+}
+
+func TestACLEDReader(t *testing.T) {
+	reader, err := NewACLEDReader(strings.NewReader(acledSample))
+	if err != nil {
+		t.Fatalf("Failed to create reader: %v", err)
+	}
+	events, rowErrs := readAll(t, reader)
+
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	first := events[0]
+	if first.GetKey() != "acled-UKR1" || first.GetTitle() != "Shelling/artillery/missile attack in Kharkiv" {
+		t.Errorf("Unexpected event: %v", first)
+	}
+	if first.GetHappenedAt() != 1706745600 || first.GetLocation().GetAdministrativeArea() != "Kharkivska" {
+		t.Errorf("Unexpected time or location: %v", first)
+	}
+	if len(first.GetTags()) != 4 || first.GetTags()[1] != "political_violence" {
+		t.Errorf("Unexpected tags: %v", first.GetTags())
+	}
+	if events[1].GetHappenedAt() != 1706745600 {
+		t.Errorf("Expected long date format to parse, got %d", events[1].GetHappenedAt())
+	}
+
+	if len(rowErrs) != 1 || rowErrs[0].Line != 5 {
+		t.Errorf("Expected one error on line 5, got %v", rowErrs)
+	}
+
+	t.Run("Missing Columns", func(t *testing.T) {
+		if _, err := NewACLEDReader(strings.NewReader("event_id_cnty,notes\n")); err == nil {
+			t.Error("Expected error for missing columns")
+		}
+	})
+}
+
+func TestGDELTReader(t *testing.T) {
+	input := strings.Join([]string{
+		gdeltRow(map[int]string{
+			gdeltGlobalEventID: "1001", gdeltDay: "20240201", gdeltActor1Name: "RUSSIA", gdeltActor2Name: "UKRAINE",
+			gdeltEventCode: "190", gdeltEventRootCode: "19", gdeltQuadClass: "4",
+			gdeltActionGeoName: "Kharkiv, Kharkiv, Ukraine", gdeltActionGeoLat: "49.9808", gdeltActionGeoLong: "36.2527",
+			gdeltSourceURL: "https://example.com/news",
+		}),
+		gdeltRow(map[int]string{gdeltGlobalEventID: "1002", gdeltDay: "20240201", gdeltEventRootCode: "04"}),
+		"too\tshort",
+	}, "\n")
+
+	events, rowErrs := readAll(t, NewGDELTReader(strings.NewReader(input)))
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.GetKey() != "gdelt-1001" || event.GetTitle() != "Fight: RUSSIA / UKRAINE" {
+		t.Errorf("Unexpected event: %v", event)
+	}
+	if event.GetDescription() != "https://example.com/news" || event.GetLocation().GetLatitude() != float32(49.9808) {
+		t.Errorf("Unexpected description or location: %v", event)
+	}
+	if len(event.GetTags()) != 3 || event.GetTags()[2] != "material_conflict" {
+		t.Errorf("Unexpected tags: %v", event.GetTags())
+	}
+	if len(rowErrs) != 2 || rowErrs[0].Line != 2 || rowErrs[1].Line != 3 {
+		t.Errorf("Expected errors on lines 2 and 3, got %v", rowErrs)
+	}
+}
+
+// memoryStore keeps inserted events in memory.
+type memoryStore struct {
+	events map[string]*model.Event
+	reject map[string]bool
+}
+
+func (m *memoryStore) ExistingKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	for _, key := range keys {
+		if _, ok := m.events[key]; ok {
+			existing[key] = true
+		}
+	}
+	return existing, nil
+}
+
+func (m *memoryStore) InsertEvents(ctx context.Context, events []*model.Event) (int, int, map[int]error, error) {
+	inserted := 0
+	rejected := make(map[int]error)
+	for i, event := range events {
+		if m.reject[event.GetKey()] {
+			rejected[i] = errors.New("document rejected")
+			continue
+		}
+		m.events[event.GetKey()] = event
+		inserted++
+	}
+	return inserted, 0, rejected, nil
+}
+
+func TestImport(t *testing.T) {
+	store := &memoryStore{events: map[string]*model.Event{"acled-UKR2": {Key: "acled-UKR2"}}}
+
+	t.Run("Dry Run", func(t *testing.T) {
+		reader, _ := NewACLEDReader(strings.NewReader(acledSample))
+		progress, err := Import(context.Background(), store, reader, Options{DryRun: true}, nil)
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+		if progress.Imported != 1 || len(store.events) != 1 {
+			t.Errorf("Expected nothing to be written in a dry run, got %+v with %d stored", progress, len(store.events))
+		}
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		reader, _ := NewACLEDReader(strings.NewReader(acledSample))
+		reports := 0
+		progress, err := Import(context.Background(), store, reader, Options{BatchSize: 1}, func(p Progress) error {
+			reports++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+
+		// UKR1 is repeated in the file and UKR2 is already stored
+		if progress.Rows != 4 || progress.Imported != 1 || progress.Duplicates != 2 || progress.Failed != 1 {
+			t.Errorf("Unexpected progress: %+v", progress)
+		}
+		if _, ok := store.events["acled-UKR1"]; !ok || store.events["acled-UKR1"].GetUpdatedAt() == 0 {
+			t.Error("Expected UKR1 to be stored with an update time")
+		}
+		if reports < 2 {
+			t.Errorf("Expected progress after every batch, got %d reports", reports)
+		}
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		store := &memoryStore{events: map[string]*model.Event{}, reject: map[string]bool{"acled-UKR1": true}}
+		reader, _ := NewACLEDReader(strings.NewReader(acledSample))
+		var rowErrs []*RowError
+		progress, err := Import(context.Background(), store, reader, Options{}, func(p Progress) error {
+			rowErrs = append(rowErrs, p.Errors...)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected the import to go on, got %v", err)
+		}
+		if progress.Imported != 1 || progress.Failed != 2 {
+			t.Errorf("Expected UKR2 imported and UKR1 and UKR3 failed, got %+v", progress)
+		}
+		if len(rowErrs) != 2 || rowErrs[1].Line != 2 {
+			t.Errorf("Expected the rejected UKR1 on line 2, got %v", rowErrs)
+		}
+	})
+}
+
+func TestGeoJSONReader(t *testing.T) {
+	input := `{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "id": "a", "geometry": {"type": "Point", "coordinates": [36.25, 50]},
+			 "properties": {"name": "Strike", "when": "2024-02-01", "labels": "artillery, night", "level": "confidential"}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [30.5, 50.4]},
+			 "properties": {"name": "Protest", "when": 1706745600, "labels": ["civil"]}},
+			{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]},
+			 "properties": {"name": "Road", "when": 1706745600}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [30.5, 50.4]},
+			 "properties": {"name": "Later", "when": "yesterday"}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [30.5, 50.4]},
+			 "properties": {"name": "Secret", "when": 1706745600, "level": "top secret"}}
+		]
+	}`
+
+	reader, err := NewGeoJSONReader([]byte(input), PropertyMapping{
+		Title: "name", HappenedAt: "when", Tags: "labels", Sensitivity: "level",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create reader: %v", err)
+	}
+	events, rowErrs := readAll(t, reader)
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	first := events[0]
+	if first.GetKey() != "geojson-a" || first.GetTitle() != "Strike" || first.GetHappenedAt() != 1706745600 {
+		t.Errorf("Unexpected event: %v", first)
+	}
+	if first.GetSensitivity() != model.Sensitivity_SENSITIVITY_CONFIDENTIAL {
+		t.Errorf("Expected confidential sensitivity, got %v", first.GetSensitivity())
+	}
+	if len(first.GetTags()) != 2 || first.GetTags()[1] != "night" {
+		t.Errorf("Unexpected tags: %v", first.GetTags())
+	}
+	if first.GetLocation().GetLatitude() != 50 || first.GetLocation().GetLongitude() != 36.25 {
+		t.Errorf("Unexpected location: %v", first.GetLocation())
+	}
+	if events[1].GetKey() != "" {
+		t.Errorf("Expected features without id to have no key, got %s", events[1].GetKey())
+	}
+
+	if len(rowErrs) != 3 || rowErrs[0].Line != 2 || rowErrs[1].Line != 3 || rowErrs[2].Line != 4 {
+		t.Errorf("Expected errors for features 2, 3 and 4, got %v", rowErrs)
+	}
+
+	t.Run("Long Key", func(t *testing.T) {
+		input := `{"type": "FeatureCollection", "features": [
+			{"type": "Feature", "id": "` + strings.Repeat("x", 300) + `", "geometry": {"type": "Point", "coordinates": [30.5, 50.4]},
+			 "properties": {"title": "Protest", "happened_at": 1706745600}}
+		]}`
+		reader, err := NewGeoJSONReader([]byte(input), PropertyMapping{})
+		if err != nil {
+			t.Fatalf("Failed to create reader: %v", err)
+		}
+		if events, rowErrs := readAll(t, reader); len(events) != 0 || len(rowErrs) != 1 || rowErrs[0].Line != 0 {
+			t.Errorf("Expected the feature to be rejected, got %v and %v", events, rowErrs)
+		}
+	})
+
+	t.Run("Not A Collection", func(t *testing.T) {
+		if _, err := NewGeoJSONReader([]byte(`{"type": "Feature"}`), PropertyMapping{}); err == nil {
+			t.Error("Expected error for a single feature")
+		}
+	})
+}
+
+func TestGPX(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <metadata><name>Patrol</name><time>2024-02-01T10:00:00Z</time></metadata>
+  <wpt lat="50.1" lon="36.2"><name>Checkpoint</name></wpt>
+  <trk>
+    <trkseg>
+      <trkpt lat="50" lon="36"><time>2024-02-01T10:00:00Z</time></trkpt>
+      <trkpt lat="50.00001" lon="36.001"><time>2024-02-01T10:01:00Z</time></trkpt>
+      <trkpt lat="50" lon="36.002"><time>2024-02-01T10:02:00Z</time></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+	doc, err := ParseGPX([]byte(input))
+	if err != nil {
+		t.Fatalf("Failed to parse GPX: %v", err)
+	}
+
+	result := doc.Events(GPXOptions{Tolerance: 10, Tags: []string{"patrol"}}, time.Now())
+	if result.TrackPoints != 3 {
+		t.Errorf("Expected 3 track points, got %d", result.TrackPoints)
+	}
+	if len(result.Paths) != 1 || len(result.Paths[0]) != 2 {
+		t.Fatalf("Expected one path of 2 events, got %v", result.Paths)
+	}
+	last := result.Paths[0][1]
+	if last.GetTitle() != "Patrol (2/2)" || last.GetHappenedAt() != 1706781720 {
+		t.Errorf("Unexpected track event: %v", last)
+	}
+	if len(last.GetTags()) != 3 || last.GetTags()[0] != "patrol" || last.GetTags()[2] != "track" {
+		t.Errorf("Unexpected tags: %v", last.GetTags())
+	}
+
+	if len(result.Waypoints) != 1 {
+		t.Fatalf("Expected 1 waypoint, got %d", len(result.Waypoints))
+	}
+	waypoint := result.Waypoints[0]
+	if waypoint.GetTitle() != "Checkpoint" || waypoint.GetHappenedAt() != 1706781600 {
+		t.Errorf("Expected waypoint to take the document time, got %v", waypoint)
+	}
+
+	t.Run("Invalid Coordinates", func(t *testing.T) {
+		if _, err := ParseGPX([]byte(`<gpx><wpt lat="95" lon="0"/></gpx>`)); err == nil {
+			t.Error("Expected error for out of range latitude")
+		}
+	})
+}
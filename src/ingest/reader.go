@@ -0,0 +1,130 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// Reader yields events parsed from an import file, one per call. It returns
+// a *RowError for rows that cannot be imported, after which reading can go
+// on, and io.EOF once the input is exhausted. Line returns the line, or
+// feature index, of the row last read.
+type Reader interface {
+	Read() (*model.Event, error)
+	Line() int
+}
+
+// RowError describes why a row of an import file was rejected. Line is the
+// line of CSV input, or the index of the feature for GeoJSON.
+type RowError struct {
+	Line    int
+	Message string
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+func rowErrorf(line int, format string, args ...interface{}) *RowError {
+	return &RowError{Line: line, Message: fmt.Sprintf(format, args...)}
+}
+
+// recordLine returns the line a CSV record starts on, which differs from the
+// record number when quoted fields span lines. Malformed records become row
+// errors; any other read error is returned as is and ends the import.
+func recordLine(r *csv.Reader, err error) (int, error) {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.StartLine, rowErrorf(parseErr.StartLine, "%v", parseErr.Err)
+	}
+	if err != nil {
+		return 0, err
+	}
+	line, _ := r.FieldPos(0)
+	return line, nil
+}
+
+// maxKeyLength is the longest document key ArangoDB accepts, in bytes.
+const maxKeyLength = 254
+
+var invalidKeyChars = regexp.MustCompile(`[^a-zA-Z0-9_\-:.@()+,=;$!*'%]`)
+
+// SourceKey derives the document key of an imported event from the dataset
+// and the identifier it has there, so importing the same row twice maps to
+// the same document.
+func SourceKey(dataset, id string) string {
+	return dataset + "-" + invalidKeyChars.ReplaceAllString(id, "_")
+}
+
+// sourceKey returns the SourceKey of a row, rejecting identifiers too long
+// to become a document key.
+func sourceKey(line int, dataset, id string) (string, error) {
+	key := SourceKey(dataset, id)
+	if len(key) > maxKeyLength {
+		return "", rowErrorf(line, "identifier makes a document key longer than %d bytes", maxKeyLength)
+	}
+	return key, nil
+}
+
+// parseCoordinates parses and range checks a latitude and longitude.
+func parseCoordinates(line int, lat, lon string) (float32, float32, error) {
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 32)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return 0, 0, rowErrorf(line, "invalid latitude %q", lat)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lon), 32)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return 0, 0, rowErrorf(line, "invalid longitude %q", lon)
+	}
+	return float32(latitude), float32(longitude), nil
+}
+
+// tagOf normalizes a category into a tag.
+func tagOf(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), "_"))
+}
+
+// appendTags adds the non-empty values as tags, skipping duplicates.
+func appendTags(tags []string, values ...string) []string {
+	for _, value := range values {
+		tag := tagOf(value)
+		if tag == "" {
+			continue
+		}
+		duplicate := false
+		for _, existing := range tags {
+			if existing == tag {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Formats supported by NewReader.
+const (
+	FormatACLED = "acled"
+	FormatGDELT = "gdelt"
+)
+
+// NewReader creates a reader for the named format.
+func NewReader(format string, r io.Reader) (Reader, error) {
+	switch strings.ToLower(format) {
+	case FormatACLED:
+		return NewACLEDReader(r)
+	case FormatGDELT:
+		return NewGDELTReader(r), nil
+	}
+	return nil, fmt.Errorf("unknown import format %q", format)
+}
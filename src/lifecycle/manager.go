@@ -0,0 +1,156 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// DefaultShutdownTimeout bounds how long draining requests and closing
+// clients may take unless configured otherwise.
+const DefaultShutdownTimeout = 15 * time.Second
+
+// Manager runs the servers of the service until it receives SIGINT or
+// SIGTERM, or until one of them fails, and then stops everything in the
+// reverse order it was registered.
+type Manager struct {
+	timeout time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	failed chan error
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex
+	stops []stopFunc
+}
+
+type stopFunc struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// NewManager creates a manager giving shutdown at most timeout.
+func NewManager(timeout time.Duration) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		timeout: timeout,
+		ctx:     ctx,
+		cancel:  cancel,
+		failed:  make(chan error, 1),
+	}
+}
+
+// Context is done once shutdown starts. Background workers should stop with
+// it.
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// Go runs a server until it returns. An error other than the one servers
+// return once stopped shuts the whole service down.
+func (m *Manager) Go(name string, serve func() error) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		err := serve()
+		if err == nil || errors.Is(err, http.ErrServerClosed) || errors.Is(err, grpc.ErrServerStopped) {
+			return
+		}
+		select {
+		case m.failed <- fmt.Errorf("%s: %w", name, err):
+		default:
+		}
+	}()
+}
+
+// OnStop registers a function releasing a server or client. They run in the
+// reverse order of registration, so servers registered after the clients
+// they use are stopped before them.
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stops = append(m.stops, stopFunc{name: name, stop: stop})
+}
+
+// Run blocks until ctx is done, a termination signal arrives or a server
+// fails, then stops everything. It returns the failure that caused the
+// shutdown, or the errors met while stopping.
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	var cause error
+	select {
+	case <-ctx.Done():
+		logrus.Info("Shutting down")
+	case cause = <-m.failed:
+		logrus.WithError(cause).Error("Shutting down after a server failed")
+	}
+	m.cancel()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	m.mu.Lock()
+	stops := m.stops
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(stops) - 1; i >= 0; i-- {
+		s := stops[i]
+		if err := s.stop(shutdownCtx); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error": err,
+				"name":  s.name,
+			}).Error("failed to stop cleanly")
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+		}
+	}
+
+	// Give servers the rest of the timeout to return from Serve
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		errs = append(errs, fmt.Errorf("servers still running after %s", m.timeout))
+	}
+
+	if cause != nil {
+		return cause
+	}
+	return errors.Join(errs...)
+}
+
+// StopGRPC drains in-flight RPCs, forcing the remaining ones closed when ctx
+// ends first.
+func StopGRPC(server *grpc.Server) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			server.Stop()
+			return fmt.Errorf("forced gRPC server to stop: %w", ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestManager(t *testing.T) {
+	t.Run("Stops In Reverse Order", func(t *testing.T) {
+		m := NewManager(time.Second)
+
+		var order []string
+		for _, name := range []string{"client", "grpc", "http"} {
+			name := name
+			m.OnStop(name, func(ctx context.Context) error {
+				order = append(order, name)
+				return nil
+			})
+		}
+
+		stopped := make(chan struct{})
+		m.Go("server", func() error {
+			<-m.Context().Done()
+			close(stopped)
+			return http.ErrServerClosed
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := m.Run(ctx); err != nil {
+			t.Fatalf("Expected clean shutdown, got %v", err)
+		}
+
+		<-stopped
+		if len(order) != 3 || order[0] != "http" || order[1] != "grpc" || order[2] != "client" {
+			t.Errorf("Expected http, grpc then client, got %v", order)
+		}
+	})
+
+	t.Run("Server Failure", func(t *testing.T) {
+		m := NewManager(time.Second)
+		failure := errors.New("address already in use")
+		m.Go("http", func() error { return failure })
+
+		err := m.Run(context.Background())
+		if !errors.Is(err, failure) {
+			t.Errorf("Expected the server failure, got %v", err)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		m := NewManager(10 * time.Millisecond)
+		m.OnStop("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := m.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded, got %v", err)
+		}
+	})
+}
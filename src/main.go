@@ -2,47 +2,88 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"flag"
+	"io"
 	"net"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	gwRuntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/audit"
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/geovision/src/cache"
+	"github.com/omnsight/geovision/src/config"
+	"github.com/omnsight/geovision/src/export"
+	"github.com/omnsight/geovision/src/geocoding"
+	"github.com/omnsight/geovision/src/health"
+	"github.com/omnsight/geovision/src/lifecycle"
+	"github.com/omnsight/geovision/src/metrics"
+	"github.com/omnsight/geovision/src/ratelimit"
 	"github.com/omnsight/geovision/src/services"
+	"github.com/omnsight/geovision/src/tak"
+	"github.com/omnsight/geovision/src/tracing"
 	"github.com/omnsight/omniscent-library/src/clients"
-	"github.com/omnsight/omniscent-library/src/constants"
 	"github.com/omnsight/omniscent-library/src/logging"
 	"github.com/omnsight/omniscent-library/src/middleware"
 )
 
 func main() {
-	// ---- 1. Start the gRPC Server (your logic) ----
-	// Get gRPC address from environment variable or use default
-	grpcPort := os.Getenv(constants.GrpcPort)
-	if grpcPort == "" {
-		logrus.Fatalf("missing environment variable %s", constants.GrpcPort)
+	// Run a one-off subcommand instead of the server when asked to
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(os.Args[2:]))
 	}
 
-	serverPort := os.Getenv(constants.ServerPort)
-	if serverPort == "" {
-		logrus.Fatalf("missing environment variable %s", constants.ServerPort)
-	}
+	configPath := flag.String("config", os.Getenv(config.Path), "configuration file, in YAML or TOML")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration, with secrets masked, and exit")
+	flag.Parse()
 
-	clientId := os.Getenv(clients.KeycloakClientID)
-	if clientId == "" {
-		logrus.Fatalf("missing environment variable %s", clients.KeycloakClientID)
+	// Load and validate the whole configuration before starting anything
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			logrus.Error(line)
+		}
+		logrus.Fatal("invalid configuration")
+	}
+	if *printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error": err,
+			}).Fatal("failed to print configuration")
+		}
+		return
 	}
 
-	// Create a gRPC server
-	gRPCServer := grpc.NewServer(
-		grpc.UnaryInterceptor(logging.LoggingInterceptor), grpc.UnaryInterceptor(middleware.GrpcGatewayIdentityInterceptor(clientId)),
-	)
+	// ---- 1. Start the gRPC Server (your logic) ----
+	grpcPort := cfg.GRPCPort
+	serverPort := cfg.ServerPort
+
+	// Everything started below is stopped in reverse order on shutdown
+	manager := lifecycle.NewManager(time.Duration(cfg.ShutdownTimeout))
+
+	// Export traces when an OTLP endpoint is configured
+	headers, _ := cfg.Tracing.HeaderMap()
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Endpoint, headers)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to set up tracing")
+	}
+	manager.OnStop("tracing", shutdownTracing)
 
 	// Create a new ArangoDB client
 	client, err := clients.NewArangoDBClient()
@@ -51,6 +92,74 @@ func main() {
 			"error": err,
 		}).Fatal("failed to establish ArangoDB client")
 	}
+	client.DB = tracing.InstrumentDatabase(metrics.InstrumentDatabase(client.DB))
+	manager.OnStop("arangodb", func(ctx context.Context) error {
+		return closeArango(client)
+	})
+
+	// Create a gRPC server, measuring every request before anything else runs
+	// and bounding it by its deadline. Tokens are checked on streaming calls
+	// too, before anything reads the identity of the caller.
+	deadlines := cfg.Query.Deadlines()
+	identityUnary, identityStream := auth.Interceptors(middleware.GrpcGatewayIdentityInterceptor(cfg.Keycloak.ClientID))
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		metrics.UnaryServerInterceptor,
+		deadlines.UnaryServerInterceptor,
+		logging.LoggingInterceptor,
+		identityUnary,
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		metrics.StreamServerInterceptor,
+		deadlines.StreamServerInterceptor,
+		identityStream,
+	}
+
+	// Record every call, including those rejected by the rate limiter. The
+	// recorder is closed after the gRPC server stops and before the database.
+	var auditStore audit.Store
+	var recorder *audit.Recorder
+	if cfg.Audit.Enabled {
+		auditStore, err = audit.NewArangoStore(context.Background(), client.DB, cfg.Audit.Collection)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error": err,
+			}).Fatal("failed to create audit collection")
+		}
+		sinks := []audit.Sink{auditStore}
+		if cfg.Audit.File != "" {
+			auditFile, err := audit.OpenFile(cfg.Audit.File)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"error": err,
+				}).Fatal("failed to open audit file")
+			}
+			manager.OnStop("audit file", func(ctx context.Context) error {
+				return auditFile.Close()
+			})
+			sinks = append(sinks, auditFile)
+		}
+		recorder = audit.NewRecorder(geovision.GeoService_ServiceDesc.ServiceName, cfg.Keycloak.ClientID, sinks...)
+		go recorder.Run()
+		manager.OnStop("audit log", recorder.Close)
+		unaryInterceptors = append(unaryInterceptors, recorder.UnaryServerInterceptor)
+		streamInterceptors = append(streamInterceptors, recorder.StreamServerInterceptor)
+	}
+
+	// Rate limit callers once their identity is known
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		base, roles := cfg.RateLimit.Policies()
+		limiter = ratelimit.NewLimiter(geovision.GeoService_ServiceDesc.ServiceName, cfg.Keycloak.ClientID, base, roles)
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor)
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor)
+	}
+
+	gRPCServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.MaxRecvMsgSize(cfg.MaxMessageSize),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
 
 	// Register your business logic implementation with the gRPC server
 	eventService, err := services.NewGeoService(client)
@@ -59,16 +168,144 @@ func main() {
 			"error": err,
 		}).Fatal("failed to create EventService")
 	}
+	eventService.ClientID = cfg.Keycloak.ClientID
+	eventService.RateLimiter = limiter
+	eventService.AuditLog = auditStore
+	eventService.Limits = services.Limits{
+		MaxGraphDepth:      cfg.Query.MaxGraphDepth,
+		MaxCentralityNodes: cfg.Query.MaxCentralityNodes,
+		MaxResults:         cfg.Query.MaxResults,
+		QueryMaxRuntime:    time.Duration(cfg.Query.MaxRuntime),
+		QueryMemoryLimit:   int64(cfg.Query.MemoryLimit),
+	}
+
+	// Cache the event queries, shared between replicas when Redis is set
+	if cfg.Cache.Enabled {
+		var backend cache.Backend = cache.NewLRU(cfg.Cache.Size)
+		if cfg.Cache.RedisURL != "" {
+			redisCache, err := cache.NewRedis(string(cfg.Cache.RedisURL))
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"error": err,
+				}).Fatal("failed to create Redis cache")
+			}
+			manager.OnStop("cache", func(ctx context.Context) error {
+				return redisCache.Close()
+			})
+			backend = redisCache
+		}
+		eventService.Repository = services.NewCachedRepository(eventService.Repository, backend, services.CacheOptions{
+			TTL:      time.Duration(cfg.Cache.TTL),
+			Window:   time.Duration(cfg.Cache.Window),
+			ClientID: cfg.Keycloak.ClientID,
+		})
+	}
+
+	// Load the boundaries used to fill missing admin fields of event locations
+	if cfg.Geocoding.Reverse {
+		reverseGeocoder, err := geocoding.NewReverseGeocoder(cfg.Geocoding.Admin0Path, cfg.Geocoding.Admin1Path)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error": err,
+			}).Warn("reverse geocoding disabled")
+		} else {
+			eventService.ReverseGeocoder = reverseGeocoder
+		}
+	}
+
+	// Load the gazetteer used to resolve place names
+	if cfg.Geocoding.Forward {
+		gazetteer, err := geocoding.NewGazetteer(cfg.Geocoding.GazetteerPath)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error": err,
+			}).Warn("forward geocoding disabled")
+		} else {
+			eventService.Gazetteer = gazetteer
+		}
+	}
+
 	geovision.RegisterGeoServiceServer(gRPCServer, eventService)
 
+	// Check the dependencies for readiness, over HTTP and grpc.health.v1
+	checker := health.NewChecker()
+	checker.Add("arangodb", health.Connectivity(client.Client))
+	checker.Add("events_collection", health.CollectionExists(client.DB, eventService.Collection.Name()))
+	checker.Add("events_indexes", health.IndexesExist(client.DB, eventService.Collection.Name(), services.EventIndexes))
+	checker.Add("osint_graph", health.GraphExists(client.DB, client.OsintGraph.Name()))
+
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(gRPCServer, healthServer)
+	go checker.Watch(manager.Context(), healthServer, geovision.GeoService_ServiceDesc.ServiceName)
+
 	// Enable reflection for debugging
 	reflection.Register(gRPCServer)
 
 	// Start the gRPC server in a separate goroutine
-	go func() {
-		lis, _ := net.Listen("tcp", ":"+grpcPort)
-		gRPCServer.Serve(lis)
-	}()
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to listen for gRPC")
+	}
+	manager.Go("gRPC server", func() error {
+		return gRPCServer.Serve(grpcListener)
+	})
+
+	// Push new events to TAK clients as Cursor-on-Target when enabled
+	cotTCPPort := cfg.CoT.TCPPort
+	if cfg.CoT.Enabled() {
+		// The feed stops with the manager context, before the gRPC server
+		feedOptions := cfg.CoT.Options()
+		if recorder != nil {
+			feedOptions.Audit = recorder
+		}
+		feed := tak.NewFeed(eventService, time.Duration(cfg.CoT.PollInterval), export.DefaultCotStale, feedOptions)
+		go feed.Run(manager.Context())
+
+		if cotTCPPort != "" {
+			lis, err := net.Listen("tcp", ":"+cotTCPPort)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"error": err,
+				}).Fatal("failed to listen for CoT TCP clients")
+			}
+			if cfg.CoT.TLSCert != "" {
+				tlsConfig, err := tak.TLSConfig(cfg.CoT.TLSCert, cfg.CoT.TLSKey, cfg.CoT.ClientCA)
+				if err != nil {
+					logrus.WithFields(logrus.Fields{
+						"error": err,
+					}).Fatal("failed to load CoT TLS certificates")
+				}
+				lis = tls.NewListener(lis, tlsConfig)
+			}
+			manager.Go("CoT TCP feed", func() error {
+				return feed.ServeTCP(manager.Context(), lis)
+			})
+		}
+		if len(cfg.CoT.UDPDestinations) > 0 {
+			var destinations []net.Addr
+			for _, destination := range cfg.CoT.UDPDestinations {
+				addr, err := net.ResolveUDPAddr("udp", destination)
+				if err != nil {
+					logrus.WithFields(logrus.Fields{
+						"error":       err,
+						"destination": destination,
+					}).Fatal("failed to resolve CoT UDP destination")
+				}
+				destinations = append(destinations, addr)
+			}
+			conn, err := net.ListenPacket("udp", ":0")
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"error": err,
+				}).Fatal("failed to open CoT UDP socket")
+			}
+			manager.Go("CoT UDP feed", func() error {
+				return feed.ServeUDP(manager.Context(), conn, destinations)
+			})
+		}
+	}
 
 	// ---- 2. Start the gRPC-Gateway (the connection) ----
 	ctx := context.Background()
@@ -76,19 +313,23 @@ func main() {
 	// Create a client connection to the gRPC server
 	// The gateway acts as a client - using NewClient instead of deprecated DialContext
 	conn, err := grpc.NewClient(
-		grpcPort,
+		"localhost:"+grpcPort,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"error": err,
 		}).Fatal("failed to create gRPC client")
 	}
-	defer conn.Close()
+	manager.OnStop("gateway connection", func(ctx context.Context) error {
+		return conn.Close()
+	})
+	manager.OnStop("gRPC server", lifecycle.StopGRPC(gRPCServer))
 
 	// Create the gRPC-Gateway's multiplexer (router)
 	// This mux knows how to translate HTTP routes (from proto definitions) to gRPC calls
-	gwmux := gwRuntime.NewServeMux()
+	gwmux := gwRuntime.NewServeMux(gwRuntime.WithOutgoingHeaderMatcher(ratelimit.OutgoingHeaderMatcher))
 
 	// Register all service handlers with the gateway's router
 	if err := geovision.RegisterGeoServiceHandler(ctx, gwmux, conn); err != nil {
@@ -97,19 +338,75 @@ func main() {
 		}).Fatal("failed to register GeoService handler")
 	}
 
+	// Register the file exports next to the generated routes
+	if err := export.RegisterHandlers(gwmux, geovision.NewGeoServiceClient(conn)); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to register export handlers")
+	}
+
 	// ---- 3. Start the Gin Server (the HTTP entrypoint) ----
 	// Create a Gin router
 	r := gin.Default()
+	r.Use(otelgin.Middleware(tracing.ServiceName))
+
+	// Tell the rate limiter who the HTTP clients are
+	if limiter != nil {
+		if err := r.SetTrustedProxies(cfg.RateLimit.TrustedProxies); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error": err,
+			}).Fatal("failed to set trusted proxies")
+		}
+		r.Use(ratelimit.ForwardClientIP)
+	}
 
 	// Tell Gin to proxy any requests on /v1/* to the gRPC-Gateway
 	// THIS IS THE "CONNECTION"
 	r.Any("/v1/*any", gin.WrapH(gwmux))
 
-	// Add other Gin routes as needed
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
-	})
+	// Expose Prometheus metrics
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Liveness only tells that the process serves; readiness checks the
+	// dependencies. /health is kept for existing probes.
+	r.GET("/livez", health.Live)
+	r.GET("/health", health.Live)
+	r.GET("/readyz", checker.Ready)
 
 	// Run the Gin server
-	r.Run(":" + serverPort)
+	httpListener, err := net.Listen("tcp", ":"+serverPort)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to listen for HTTP")
+	}
+	httpServer := &http.Server{Handler: r}
+	manager.Go("HTTP server", func() error {
+		return httpServer.Serve(httpListener)
+	})
+	manager.OnStop("HTTP server", httpServer.Shutdown)
+
+	// Report not serving first so that load balancers stop routing here
+	manager.OnStop("gRPC health", func(ctx context.Context) error {
+		healthServer.Shutdown()
+		return nil
+	})
+
+	logrus.Infof("serving gRPC on :%s and HTTP on :%s", grpcPort, serverPort)
+	if err := manager.Run(context.Background()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("shutdown failed")
+	}
+	logrus.Info("stopped")
+}
+
+// closeArango releases the ArangoDB client. The driver only holds pooled HTTP
+// connections and exposes no Close of its own, so this closes the client if
+// it is closable and otherwise leaves the connections to the process exit.
+func closeArango(client *clients.ArangoDBClient) error {
+	if closer, ok := client.Client.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
 }
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"google.golang.org/grpc"
+)
+
+// backgroundMethod labels queries run outside of an RPC, such as by the CoT
+// feed or the import command.
+const backgroundMethod = "none"
+
+// Database records the duration of every AQL query and the rows and bytes
+// read from its cursor. Everything else goes straight to the wrapped
+// database.
+type Database struct {
+	driver.Database
+}
+
+// InstrumentDatabase wraps db so that its queries are measured.
+func InstrumentDatabase(db driver.Database) driver.Database {
+	if _, ok := db.(*Database); ok {
+		return db
+	}
+	return &Database{Database: db}
+}
+
+// Query runs the query and measures it under the RPC found in ctx.
+func (d *Database) Query(ctx context.Context, query string, bindVars map[string]interface{}) (driver.Cursor, error) {
+	method, ok := grpc.Method(ctx)
+	if !ok {
+		method = backgroundMethod
+	}
+
+	start := time.Now()
+	cursor, err := d.Database.Query(ctx, query, bindVars)
+	queryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		queryErrors.WithLabelValues(method).Inc()
+		return nil, err
+	}
+	return &Cursor{Cursor: cursor, method: method}, nil
+}
+
+// Cursor counts the documents and bytes read and records them when closed.
+type Cursor struct {
+	driver.Cursor
+
+	method string
+	rows   int
+	bytes  int
+	once   sync.Once
+}
+
+// ReadDocument reads the raw document first to measure it, then decodes it
+// into result like the driver does over its default JSON connection.
+func (c *Cursor) ReadDocument(ctx context.Context, result interface{}) (driver.DocumentMeta, error) {
+	var raw json.RawMessage
+	meta, err := c.Cursor.ReadDocument(ctx, &raw)
+	if err != nil {
+		return meta, err
+	}
+	c.rows++
+	c.bytes += len(raw)
+
+	// A null result resets the target, as the driver does
+	if raw == nil {
+		rv := reflect.ValueOf(result)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return driver.DocumentMeta{}, &json.InvalidUnmarshalError{Type: reflect.TypeOf(result)}
+		}
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+		return meta, nil
+	}
+	return meta, json.Unmarshal(raw, result)
+}
+
+// Close closes the cursor and records what was read from it.
+func (c *Cursor) Close() error {
+	c.once.Do(func() {
+		cursorRows.WithLabelValues(c.method).Observe(float64(c.rows))
+		resultBytes.WithLabelValues(c.method).Observe(float64(c.bytes))
+	})
+	return c.Cursor.Close()
+}
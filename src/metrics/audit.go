@@ -0,0 +1,15 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var auditDropped = factory.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "audit",
+	Name:      "dropped_total",
+	Help:      "Audit entries logged instead of written because the audit queue was full.",
+})
+
+// ObserveAuditDropped counts an audit entry that did not fit in the queue.
+func ObserveAuditDropped() {
+	auditDropped.Inc()
+}
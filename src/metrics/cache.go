@@ -0,0 +1,33 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	cacheLookups = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "lookups_total",
+		Help:      "Query cache lookups, by query and result (hit or miss).",
+	}, []string{"query", "result"})
+
+	cacheErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "errors_total",
+		Help:      "Query cache lookups that failed and went to the database, by query.",
+	}, []string{"query"})
+)
+
+// ObserveCacheLookup counts a lookup of the named query in the query cache.
+func ObserveCacheLookup(query string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.WithLabelValues(query, result).Inc()
+}
+
+// ObserveCacheError counts a cache failure for the named query.
+func ObserveCacheError(query string) {
+	cacheErrors.WithLabelValues(query).Inc()
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	method := "/geovision.v1.GeoService/TestMethod"
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	UnaryServerInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	UnaryServerInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Errorf(codes.InvalidArgument, "bad request")
+	})
+
+	if got := testutil.ToFloat64(rpcRequests.WithLabelValues(method, codes.OK.String())); got != 1 {
+		t.Errorf("Expected 1 OK request, got %v", got)
+	}
+	if got := testutil.ToFloat64(rpcRequests.WithLabelValues(method, codes.InvalidArgument.String())); got != 1 {
+		t.Errorf("Expected 1 InvalidArgument request, got %v", got)
+	}
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `geovision_grpc_request_duration_seconds_count{method="`+method+`"} 2`) {
+		t.Errorf("Expected the latency histogram in the output")
+	}
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var rateLimited = factory.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "grpc",
+	Name:      "rate_limited_total",
+	Help:      "RPCs rejected for exceeding the rate limit of their caller, by method.",
+}, []string{"method"})
+
+// ObserveRateLimited counts an RPC rejected by the rate limiter.
+func ObserveRateLimited(method string) {
+	rateLimited.WithLabelValues(method).Inc()
+}
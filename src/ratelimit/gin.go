@@ -0,0 +1,26 @@
+package ratelimit
+
+import (
+	"net/textproto"
+
+	"github.com/gin-gonic/gin"
+	gwRuntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// ForwardClientIP passes the address of HTTP clients, as Gin sees it, through
+// the gateway to the gRPC server, so that callers without an identity are
+// limited by it. Whatever the client sent in its place is overwritten.
+func ForwardClientIP(c *gin.Context) {
+	c.Request.Header.Set(gwRuntime.MetadataHeaderPrefix+clientIPKey, c.ClientIP())
+	c.Next()
+}
+
+// OutgoingHeaderMatcher sends the retry delay of rejected calls as the
+// standard Retry-After header, and other response metadata as the gateway
+// does by default.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if key == RetryAfterKey {
+		return textproto.CanonicalMIMEHeaderKey(key), true
+	}
+	return gwRuntime.MetadataHeaderPrefix + key, true
+}
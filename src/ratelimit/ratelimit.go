@@ -0,0 +1,247 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/geovision/src/metrics"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RetryAfterKey is the response metadata telling rejected callers how many
+// seconds to wait. The gateway sends it as the Retry-After header.
+const RetryAfterKey = "retry-after"
+
+// clientIPKey is the metadata carrying the address of HTTP clients, set by
+// ForwardClientIP.
+const clientIPKey = "x-client-ip"
+
+// sweepInterval is how often buckets left full by idle callers are dropped.
+const sweepInterval = time.Minute
+
+// Limit is a token bucket refilled with Rate requests per second and holding
+// up to Burst of them.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Policy sets the Default limit of every RPC, unless Methods has one for it
+// by name, such as GetEvents. A zero Default leaves the other RPCs to the
+// next policy.
+type Policy struct {
+	Default Limit
+	Methods map[string]Limit
+}
+
+func (p Policy) limit(method string) (Limit, bool) {
+	if limit, ok := p.Methods[method]; ok {
+		return limit, true
+	}
+	return p.Default, p.Default.Rate > 0
+}
+
+// Usage is the limit of a caller on one RPC and the requests left in its
+// bucket.
+type Usage struct {
+	Method    string
+	Limit     Limit
+	Remaining float64
+}
+
+// Limiter rate limits the RPCs of one gRPC service with a bucket per caller
+// and RPC. Callers are authenticated users, keyed by their subject, or
+// otherwise client addresses.
+type Limiter struct {
+	service  string
+	clientID string
+	base     Policy
+	roles    map[string]Policy
+	now      func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+	swept   time.Time
+}
+
+// NewLimiter limits the RPCs of service with the base policy. Callers with
+// any of the roles get the policy of that role instead, the most generous
+// one when several apply. Roles are read from the realm and from the given
+// Keycloak client.
+func NewLimiter(service, clientID string, base Policy, roles map[string]Policy) *Limiter {
+	return &Limiter{
+		service:  service,
+		clientID: clientID,
+		base:     base,
+		roles:    roles,
+		now:      time.Now,
+		buckets:  make(map[string]*rate.Limiter),
+	}
+}
+
+// UnaryServerInterceptor rejects calls over the limit of their caller.
+func (l *Limiter) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := l.allow(ctx, info.FullMethod, grpc.SetHeader); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor does the same for streaming RPCs, counting each
+// stream as one request.
+func (l *Limiter) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	setHeader := func(ctx context.Context, md metadata.MD) error {
+		return stream.SetHeader(md)
+	}
+	if err := l.allow(stream.Context(), info.FullMethod, setHeader); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+func (l *Limiter) allow(ctx context.Context, fullMethod string, setHeader func(context.Context, metadata.MD) error) error {
+	method, ok := strings.CutPrefix(fullMethod, "/"+l.service+"/")
+	if !ok {
+		return nil
+	}
+	caller, roles := l.Caller(ctx)
+	limit, ok := l.limit(method, roles)
+	if !ok {
+		return nil
+	}
+
+	now := l.now()
+	reservation := l.bucket(caller, method, limit, now).ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+	reservation.CancelAt(now)
+
+	seconds := int(math.Ceil(delay.Seconds()))
+	setHeader(ctx, metadata.Pairs(RetryAfterKey, strconv.Itoa(seconds)))
+	metrics.ObserveRateLimited(fullMethod)
+	return status.Errorf(codes.ResourceExhausted, "rate limit of %s exceeded, retry in %ds", method, seconds)
+}
+
+// Usage returns the limit of the caller on each of the given RPCs, by name,
+// and what is left of it.
+func (l *Limiter) Usage(ctx context.Context, methods []string) (string, []Usage) {
+	caller, roles := l.Caller(ctx)
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var usage []Usage
+	for _, method := range methods {
+		limit, ok := l.limit(method, roles)
+		if !ok {
+			continue
+		}
+		remaining := float64(limit.Burst)
+		if bucket, ok := l.buckets[bucketKey(caller, method)]; ok && bucket.Limit() == rate.Limit(limit.Rate) && bucket.Burst() == limit.Burst {
+			remaining = max(bucket.TokensAt(now), 0)
+		}
+		usage = append(usage, Usage{Method: method, Limit: limit, Remaining: remaining})
+	}
+	return caller, usage
+}
+
+// Caller returns the key whose buckets a call is counted against, with the
+// roles deciding its limits. Only identities whose token the identity
+// interceptors verified count; any other call is keyed by its address, so
+// forged subjects and roles neither lift the limits nor add buckets.
+func (l *Limiter) Caller(ctx context.Context) (string, []string) {
+	if identity, ok := auth.FromContext(ctx, l.clientID); ok && identity.Subject != "" {
+		return "user:" + identity.Subject, identity.Roles
+	}
+	return "ip:" + clientIP(ctx), nil
+}
+
+// limit picks the most generous limit that the roles give for the method,
+// falling back to the base policy.
+func (l *Limiter) limit(method string, roles []string) (Limit, bool) {
+	var best Limit
+	found := false
+	for _, role := range roles {
+		policy, ok := l.roles[role]
+		if !ok {
+			continue
+		}
+		if limit, ok := policy.limit(method); ok && (!found || limit.Rate > best.Rate || (limit.Rate == best.Rate && limit.Burst > best.Burst)) {
+			best, found = limit, true
+		}
+	}
+	if found {
+		return best, true
+	}
+	return l.base.limit(method)
+}
+
+// bucket returns the bucket of the caller for the method, updated to the
+// limit in case the roles of the caller changed.
+func (l *Limiter) bucket(caller, method string, limit Limit, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Full buckets are the same as new ones, so they need not be kept
+	if now.Sub(l.swept) >= sweepInterval {
+		for key, bucket := range l.buckets {
+			if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+				delete(l.buckets, key)
+			}
+		}
+		l.swept = now
+	}
+
+	key := bucketKey(caller, method)
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+		l.buckets[key] = bucket
+	} else if bucket.Limit() != rate.Limit(limit.Rate) || bucket.Burst() != limit.Burst {
+		bucket.SetLimitAt(now, rate.Limit(limit.Rate))
+		bucket.SetBurstAt(now, limit.Burst)
+	}
+	return bucket
+}
+
+func bucketKey(caller, method string) string {
+	return fmt.Sprintf("%s|%s", caller, method)
+}
+
+// clientIP returns the address of the caller. Calls through the gateway come
+// from the loopback interface, so the address forwarded by the HTTP server is
+// used for them; other callers cannot set it.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if forwarded := md.Get(clientIPKey); len(forwarded) > 0 && forwarded[0] != "" {
+				return forwarded[0]
+			}
+		}
+	}
+	return host
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/omnsight/geovision/src/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const service = "geovision.v1.GeoService"
+
+func withPeer(ctx context.Context, addr string) context.Context {
+	tcpAddr, _ := net.ResolveTCPAddr("tcp", addr)
+	return peer.NewContext(ctx, &peer.Peer{Addr: tcpAddr})
+}
+
+func withToken(ctx context.Context, payload string) context.Context {
+	token := "Bearer e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+	return auth.Verified(metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", token)))
+}
+
+func TestLimiter(t *testing.T) {
+	base := Policy{
+		Default: Limit{Rate: 1, Burst: 2},
+		Methods: map[string]Limit{"ExportEvents": {Rate: 0.1, Burst: 1}},
+	}
+	roles := map[string]Policy{
+		"analyst": {Default: Limit{Rate: 5, Burst: 10}},
+		"admin":   {Default: Limit{Rate: 50, Burst: 100}},
+	}
+	now := time.Unix(1000, 0)
+	newLimiter := func() *Limiter {
+		l := NewLimiter(service, "geovision", base, roles)
+		l.now = func() time.Time { return now }
+		return l
+	}
+	call := func(l *Limiter, ctx context.Context, method string) (metadata.MD, error) {
+		var header metadata.MD
+		err := l.allow(ctx, "/"+service+"/"+method, func(ctx context.Context, md metadata.MD) error {
+			header = md
+			return nil
+		})
+		return header, err
+	}
+
+	t.Run("Burst", func(t *testing.T) {
+		l := newLimiter()
+		ctx := withPeer(context.Background(), "203.0.113.7:4242")
+		for i := 0; i < 2; i++ {
+			if _, err := call(l, ctx, "GetEvents"); err != nil {
+				t.Fatalf("Expected call %d within the burst to pass, got %v", i+1, err)
+			}
+		}
+		header, err := call(l, ctx, "GetEvents")
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Expected ResourceExhausted over the burst, got %v", err)
+		}
+		if got := header.Get(RetryAfterKey); len(got) != 1 || got[0] != "1" {
+			t.Errorf("Expected a retry after 1 second, got %v", got)
+		}
+
+		// Other RPCs have their own bucket, and the bucket refills
+		if _, err := call(l, ctx, "GetAnomalies"); err != nil {
+			t.Errorf("Expected another RPC to pass, got %v", err)
+		}
+		now = now.Add(time.Second)
+		if _, err := call(l, ctx, "GetEvents"); err != nil {
+			t.Errorf("Expected a call to pass once refilled, got %v", err)
+		}
+	})
+
+	t.Run("Per RPC", func(t *testing.T) {
+		l := newLimiter()
+		ctx := withPeer(context.Background(), "203.0.113.7:4242")
+		call(l, ctx, "ExportEvents")
+		header, err := call(l, ctx, "ExportEvents")
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Expected ResourceExhausted, got %v", err)
+		}
+		if got := header.Get(RetryAfterKey); len(got) != 1 || got[0] != "10" {
+			t.Errorf("Expected a retry after 10 seconds, got %v", got)
+		}
+	})
+
+	t.Run("Roles", func(t *testing.T) {
+		l := newLimiter()
+		ctx := withToken(context.Background(), `{"sub": "user-1", "realm_access": {"roles": ["analyst", "admin"]}}`)
+		caller, usage := l.Usage(ctx, []string{"GetEvents"})
+		if caller != "user:user-1" {
+			t.Errorf("Expected the caller to be the subject, got %s", caller)
+		}
+		if len(usage) != 1 || usage[0].Limit.Burst != 100 || usage[0].Remaining != 100 {
+			t.Errorf("Expected the most generous role limit, got %+v", usage)
+		}
+
+		call(l, ctx, "GetEvents")
+		if _, usage := l.Usage(ctx, []string{"GetEvents"}); usage[0].Remaining != 99 {
+			t.Errorf("Expected 99 requests left, got %v", usage[0].Remaining)
+		}
+	})
+
+	t.Run("Unverified Token", func(t *testing.T) {
+		l := newLimiter()
+		payload := `{"sub": "forged", "realm_access": {"roles": ["admin"]}}`
+		token := "Bearer e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+		ctx := metadata.NewIncomingContext(withPeer(context.Background(), "203.0.113.7:4242"), metadata.Pairs("authorization", token))
+		caller, usage := l.Usage(ctx, []string{"GetEvents"})
+		if caller != "ip:203.0.113.7" {
+			t.Errorf("Expected the caller to be the address, got %s", caller)
+		}
+		if len(usage) != 1 || usage[0].Limit.Burst != 2 {
+			t.Errorf("Expected the base limit, got %+v", usage)
+		}
+	})
+
+	t.Run("Other Services", func(t *testing.T) {
+		l := newLimiter()
+		ctx := withPeer(context.Background(), "203.0.113.7:4242")
+		for i := 0; i < 5; i++ {
+			if err := l.allow(ctx, "/grpc.health.v1.Health/Check", nil); err != nil {
+				t.Fatalf("Expected other services not to be limited, got %v", err)
+			}
+		}
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	forwarded := metadata.NewIncomingContext(context.Background(), metadata.Pairs(clientIPKey, "198.51.100.1"))
+
+	if ip := clientIP(withPeer(forwarded, "127.0.0.1:5000")); ip != "198.51.100.1" {
+		t.Errorf("Expected the address forwarded by the gateway, got %s", ip)
+	}
+	if ip := clientIP(withPeer(forwarded, "203.0.113.7:5000")); ip != "203.0.113.7" {
+		t.Errorf("Expected the peer address of a direct caller, got %s", ip)
+	}
+}
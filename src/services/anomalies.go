@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/analytics"
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultGeohashPrecision = 4
+	maxGeohashPrecision     = 8
+	defaultBaselineWindows  = 8
+	maxBaselineWindows      = 52
+	defaultAnomalyThreshold = 3.0
+)
+
+// anomalyCount is the number of events sharing a region or tag in one window.
+// Window -1 is the observed window. Geohash regions come as the row and
+// column of their cell instead of a key.
+type anomalyCount struct {
+	Dimension string  `json:"dimension"`
+	Key       string  `json:"key"`
+	Cell      []int64 `json:"cell"`
+	Window    int     `json:"window"`
+	Count     int64   `json:"count"`
+}
+
+func (s *EventService) GetAnomalies(ctx context.Context, req *geovision.GetAnomaliesRequest) (*geovision.GetAnomaliesResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Getting anomalies")
+
+	// Validate the time window
+	if req.GetStartTime() == 0 || req.GetEndTime() == 0 {
+		logger.Error("both start_time and end_time are required")
+		return nil, status.Errorf(codes.InvalidArgument, "both start time and end time are required")
+	}
+	if req.GetStartTime() >= req.GetEndTime() {
+		logger.Error("start_time must be before end_time")
+		return nil, status.Errorf(codes.InvalidArgument, "start time must be before end time")
+	}
+
+	precision := int(req.GetGeohashPrecision())
+	if precision == 0 {
+		precision = defaultGeohashPrecision
+	}
+	if precision < 1 || precision > maxGeohashPrecision {
+		logger.Error("geohash_precision out of range")
+		return nil, status.Errorf(codes.InvalidArgument, "geohash precision must be between 1 and %d", maxGeohashPrecision)
+	}
+
+	windows := int(req.GetBaselineWindows())
+	if windows == 0 {
+		windows = defaultBaselineWindows
+	}
+	if windows < 1 || windows > maxBaselineWindows {
+		logger.Error("baseline_windows out of range")
+		return nil, status.Errorf(codes.InvalidArgument, "baseline windows must be between 1 and %d", maxBaselineWindows)
+	}
+
+	threshold := req.GetThreshold()
+	if threshold < 0 {
+		logger.Error("threshold must not be negative")
+		return nil, status.Errorf(codes.InvalidArgument, "threshold must not be negative")
+	}
+	if threshold == 0 {
+		threshold = defaultAnomalyThreshold
+	}
+
+	// The baseline is made of equally long windows directly preceding the
+	// requested one.
+	length := req.GetEndTime() - req.GetStartTime()
+	baselineStart := req.GetStartTime() - int64(windows)*length
+
+	// Geohash cells are grouped by their row and column in the geohash grid,
+	// since AQL cannot compute geohashes itself.
+	region := "key: doc.location.country_code"
+	grouping := req.GetRegionGrouping()
+	if grouping == geovision.RegionGrouping_REGION_GROUPING_GEOHASH {
+		region = `cell: doc.location.latitude == null || doc.location.longitude == null ? null : [
+			MIN([FLOOR((doc.location.latitude + 90) / 180 * @lat_cells), @lat_cells - 1]),
+			MIN([FLOOR((doc.location.longitude + 180) / 360 * @lon_cells), @lon_cells - 1])]`
+	}
+
+	// Windows are counted backwards from the start of the requested one
+	query := `
+		FOR doc IN @@collection
+			FILTER doc.happened_at >= @baseline_start && doc.happened_at < @end_time
+			LET window = doc.happened_at >= @start_time ? -1 : FLOOR((@start_time - 1 - doc.happened_at) / @length)
+			LET keys = APPEND(
+				[{ dimension: "region", ` + region + ` }],
+				(FOR tag IN doc.tags || [] RETURN { dimension: "tag", key: tag })
+			)
+			FOR item IN keys
+				FILTER (item.key != null && item.key != "") || item.cell != null
+				COLLECT dimension = item.dimension, key = item.key, cell = item.cell, window_index = window WITH COUNT INTO n
+				RETURN { dimension: dimension, key: key, cell: cell, window: window_index, count: n }
+	`
+	bindVars := map[string]interface{}{
+		"baseline_start": baselineStart,
+		"start_time":     req.GetStartTime(),
+		"end_time":       req.GetEndTime(),
+		"length":         length,
+		"@collection":    s.Collection.Name(),
+	}
+	if grouping == geovision.RegionGrouping_REGION_GROUPING_GEOHASH {
+		bindVars["lat_cells"], bindVars["lon_cells"] = geo.GeohashGrid(precision)
+	}
+
+	// Execute query
+	cursor, err := s.DBClient.DB.Query(s.queryContext(ctx), query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to execute AQL query for getting anomalies")
+		return nil, queryError(ctx, err)
+	}
+	defer cursor.Close()
+
+	regions := analytics.NewBaseline(windows)
+	tags := analytics.NewBaseline(windows)
+
+	for {
+		var row anomalyCount
+		_, err := cursor.ReadDocument(ctx, &row)
+
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			if readFailed(err) {
+				logger.WithError(err).Error("failed to read anomalies")
+				return nil, queryError(ctx, err)
+			}
+			logger.WithError(err).Warn("skipping malformed count in stream")
+			continue
+		}
+
+		baseline := tags
+		if row.Dimension == "region" {
+			baseline = regions
+			if grouping == geovision.RegionGrouping_REGION_GROUPING_GEOHASH {
+				if len(row.Cell) != 2 {
+					logger.WithField("cell", row.Cell).Warn("skipping malformed geohash cell")
+					continue
+				}
+				row.Key = geo.GeohashCell(row.Cell[0], row.Cell[1], precision)
+			}
+		}
+		if row.Window < 0 {
+			baseline.AddObserved(row.Key, row.Count)
+		} else {
+			baseline.AddHistorical(row.Key, row.Window, row.Count)
+		}
+	}
+
+	resp := &geovision.GetAnomaliesResponse{
+		BaselineStartTime: baselineStart,
+		BaselineEndTime:   req.GetStartTime(),
+	}
+	resp.Anomalies = append(resp.Anomalies, toAnomalies(geovision.AnomalyDimension_ANOMALY_DIMENSION_REGION, regions.Deviations(threshold))...)
+	resp.Anomalies = append(resp.Anomalies, toAnomalies(geovision.AnomalyDimension_ANOMALY_DIMENSION_TAG, tags.Deviations(threshold))...)
+	sort.SliceStable(resp.Anomalies, func(i, j int) bool {
+		return math.Abs(resp.Anomalies[i].GetZScore()) > math.Abs(resp.Anomalies[j].GetZScore())
+	})
+
+	return resp, nil
+}
+
+func toAnomalies(dimension geovision.AnomalyDimension, deviations []analytics.Deviation) []*geovision.Anomaly {
+	anomalies := make([]*geovision.Anomaly, 0, len(deviations))
+	for _, d := range deviations {
+		direction := geovision.AnomalyDirection_ANOMALY_DIRECTION_SPIKE
+		if d.ZScore < 0 {
+			direction = geovision.AnomalyDirection_ANOMALY_DIRECTION_DROP
+		}
+		anomalies = append(anomalies, &geovision.Anomaly{
+			Dimension: dimension,
+			Key:       d.Key,
+			Direction: direction,
+			Observed:  d.Observed,
+			Expected:  d.Expected,
+			Stddev:    d.StdDev,
+			ZScore:    d.ZScore,
+		})
+	}
+	return anomalies
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/audit"
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+func (s *EventService) QueryAuditLog(ctx context.Context, req *geovision.QueryAuditLogRequest) (*geovision.QueryAuditLogResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Querying audit log")
+
+	// Only admins may see who queried what
+	identity, _ := auth.FromContext(ctx, s.ClientID)
+	if !identity.HasRole(auth.AdminRole) {
+		logger.Error("caller is not an admin")
+		return nil, status.Errorf(codes.PermissionDenied, "admin role is required")
+	}
+
+	if s.AuditLog == nil {
+		logger.Error("audit log is not configured")
+		return nil, status.Errorf(codes.FailedPrecondition, "audit log is not configured")
+	}
+
+	// Validate the request
+	if req.GetLimit() < 0 || req.GetLimit() > maxAuditLimit {
+		logger.Error("limit out of range")
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxAuditLimit)
+	}
+	if req.GetStartTime() != 0 && req.GetEndTime() != 0 && req.GetStartTime() > req.GetEndTime() {
+		logger.Error("start_time must be before end_time")
+		return nil, status.Errorf(codes.InvalidArgument, "start time must be before end time")
+	}
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultAuditLimit
+	}
+
+	entries, err := s.AuditLog.Query(s.queryContext(ctx), audit.Filter{
+		Subject:        req.GetSubject(),
+		RPC:            req.GetRpc(),
+		StartTime:      req.GetStartTime(),
+		EndTime:        req.GetEndTime(),
+		MinSensitivity: int32(req.GetMinSensitivity()),
+		Limit:          limit,
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to query audit log")
+		return nil, queryError(ctx, err)
+	}
+
+	resp := &geovision.QueryAuditLogResponse{}
+	for _, entry := range entries {
+		filters, err := structpb.NewStruct(entry.Filters)
+		if err != nil {
+			logger.WithError(err).Warn("dropping unreadable audit filters")
+		}
+		resp.Entries = append(resp.Entries, &geovision.AuditEntry{
+			Time:          entry.Time,
+			Subject:       entry.Subject,
+			Username:      entry.Username,
+			Roles:         entry.Roles,
+			Authenticated: entry.Authenticated,
+			Rpc:           entry.RPC,
+			Filters:       filters,
+			Results:       int64(entry.Results),
+			Sensitivity:   model.Sensitivity(entry.Sensitivity),
+			Code:          entry.Code,
+			DurationMs:    entry.DurationMs,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultBackfillBatchSize = 500
+	maxBackfillBatchSize     = 5000
+)
+
+func (s *EventService) BackfillEventLocations(ctx context.Context, req *geovision.BackfillEventLocationsRequest) (*geovision.BackfillEventLocationsResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Backfilling event locations")
+
+	// Only admins may rewrite stored documents
+	identity, _ := auth.FromContext(ctx, s.ClientID)
+	if !identity.HasRole(auth.AdminRole) {
+		logger.Error("caller is not an admin")
+		return nil, status.Errorf(codes.PermissionDenied, "admin role is required")
+	}
+
+	if s.ReverseGeocoder == nil {
+		logger.Error("reverse geocoding is not configured")
+		return nil, status.Errorf(codes.FailedPrecondition, "reverse geocoding is not configured")
+	}
+
+	batchSize := int(req.GetBatchSize())
+	if batchSize < 0 || batchSize > maxBackfillBatchSize {
+		logger.Error("batch_size out of range")
+		return nil, status.Errorf(codes.InvalidArgument, "batch size must be between 1 and %d", maxBackfillBatchSize)
+	}
+	if batchSize == 0 {
+		batchSize = defaultBackfillBatchSize
+	}
+
+	// Each call handles one page of candidates after the key of the previous
+	// one, so that large collections are backfilled within the RPC deadline
+	query := `
+		FOR doc IN @@collection
+			FILTER doc._key > @after
+			FILTER doc.location != null
+			FILTER doc.location.country_code IN [null, ""] || doc.location.administrative_area IN [null, ""]
+			SORT doc._key
+			LIMIT @limit
+			RETURN { _key: doc._key, location: doc.location }
+	`
+
+	cursor, err := s.DBClient.DB.Query(s.queryContext(ctx), query, map[string]interface{}{
+		"@collection": s.Collection.Name(),
+		"after":       req.GetPageToken(),
+		"limit":       batchSize,
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to execute AQL query for backfilling locations")
+		return nil, queryError(ctx, err)
+	}
+	defer cursor.Close()
+
+	resp := &geovision.BackfillEventLocationsResponse{}
+	keys := make([]string, 0, batchSize)
+	patches := make([]map[string]interface{}, 0, batchSize)
+	// The cache and the CoT feed notice changes by their updated_at
+	now := time.Now().Unix()
+	rows, last := 0, ""
+
+	for {
+		var event model.Event
+		_, err := cursor.ReadDocument(ctx, &event)
+
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			if readFailed(err) {
+				logger.WithError(err).Error("failed to read events for backfilling locations")
+				return nil, queryError(ctx, err)
+			}
+			rows++
+			logger.WithError(err).Warn("skipping malformed event in stream")
+			continue
+		}
+
+		rows++
+		resp.Scanned++
+		last = event.GetKey()
+		if !s.ReverseGeocoder.Fill(event.GetLocation()) {
+			continue
+		}
+
+		resp.Updated++
+		if req.GetDryRun() {
+			continue
+		}
+
+		keys = append(keys, event.GetKey())
+		patches = append(patches, map[string]interface{}{
+			"location": map[string]interface{}{
+				"country_code":        event.GetLocation().GetCountryCode(),
+				"administrative_area": event.GetLocation().GetAdministrativeArea(),
+			},
+			"updated_at": now,
+		})
+	}
+
+	if len(keys) > 0 {
+		if _, _, err := s.Collection.UpdateDocuments(ctx, keys, patches); err != nil {
+			logger.WithError(err).Error("failed to update event locations")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+	}
+	// A full page may be followed by more candidates
+	if rows == batchSize && last != "" {
+		resp.NextPageToken = last
+	}
+
+	logger.Infof("Backfilled %d of %d event locations", resp.Updated, resp.Scanned)
+	return resp, nil
+}
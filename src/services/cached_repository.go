@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/geovision/src/cache"
+	"github.com/omnsight/geovision/src/metrics"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"google.golang.org/protobuf/proto"
+)
+
+// versionInterval is how long the latest updated_at is trusted before it is
+// checked again. Cached results are at most this much older than a write.
+const versionInterval = time.Second
+
+// cachedEvents names the event query in cache keys and metrics.
+const cachedEvents = "events"
+
+// CacheOptions tune a CachedRepository.
+type CacheOptions struct {
+	// TTL bounds how long results are kept; it also bounds how long deleted
+	// events may still be served, since deletions leave updated_at alone
+	TTL time.Duration
+	// Window widens the time range of event queries to whole multiples of
+	// it, so that clients asking for the last 24 hours a few seconds apart
+	// share one cached result. Zero caches exact ranges only.
+	Window time.Duration
+	// ClientID is the Keycloak client whose roles make up the clearance of
+	// the caller
+	ClientID string
+}
+
+// CachedRepository answers event range queries from a cache in front of
+// another repository. Only the events are cached. Keys hold the normalized
+// query, the clearance of the caller and the latest updated_at of all
+// events, so that results are never shared between callers seeing different
+// data and are dropped by any write to an event. Relations, entities and
+// event graphs change without touching that version, so the relations
+// between cached events are loaded fresh on every query, and related
+// entities and event graphs are not cached.
+type CachedRepository struct {
+	EventRepository
+
+	backend cache.Backend
+	opts    CacheOptions
+	now     func() time.Time
+
+	mu       sync.Mutex
+	version  int64
+	checked  time.Time
+	checking bool
+}
+
+// NewCachedRepository caches the queries of repository in backend.
+func NewCachedRepository(repository EventRepository, backend cache.Backend, opts CacheOptions) *CachedRepository {
+	return &CachedRepository{
+		EventRepository: repository,
+		backend:         backend,
+		opts:            opts,
+		now:             time.Now,
+	}
+}
+
+func (r *CachedRepository) EventsInRange(ctx context.Context, filter EventFilter) ([]*model.Event, []*model.Relation, error) {
+	// Query the widened range and cut the result down to the requested one
+	widened := filter
+	if window := int64(r.opts.Window / time.Second); window > 0 {
+		widened.StartTime = filter.StartTime - mod(filter.StartTime, window)
+		widened.EndTime = filter.EndTime - mod(filter.EndTime, window) + window - 1
+	}
+
+	// A miss loads the relations along with the events, which saves the
+	// second query below
+	var result geovision.GetEventsResponse
+	var loaded []*model.Relation
+	missed := false
+	key := fmt.Sprintf("%d|%d|%s", widened.StartTime, widened.EndTime, bboxKey(filter))
+	err := r.cached(ctx, cachedEvents, key, &result, func() (err error) {
+		missed = true
+		result.Events, loaded, err = r.EventRepository.EventsInRange(ctx, widened)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var events []*model.Event
+	ids := make(map[string]bool, len(result.Events))
+	eventIDs := make([]string, 0, len(result.Events))
+	for _, event := range result.Events {
+		if event.GetHappenedAt() >= filter.StartTime && event.GetHappenedAt() <= filter.EndTime {
+			events = append(events, event)
+			ids[event.GetId()] = true
+			eventIDs = append(eventIDs, event.GetId())
+		}
+	}
+
+	if !missed {
+		if loaded, err = r.EventRepository.RelationsBetween(ctx, eventIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+	var relations []*model.Relation
+	for _, relation := range loaded {
+		if ids[relation.GetFrom()] && ids[relation.GetTo()] {
+			relations = append(relations, relation)
+		}
+	}
+	return events, relations, nil
+}
+
+// cached decodes the result stored for the query into value, or runs load to
+// fill value and stores it. Protobuf messages are stored in their binary
+// form, anything else as JSON. Cache failures are logged and fall back to
+// load.
+func (r *CachedRepository) cached(ctx context.Context, query, key string, value interface{}, load func() error) error {
+	logger := logging.GetLogger(ctx)
+
+	version, err := r.latestUpdate(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to check for updated events, bypassing cache")
+		metrics.ObserveCacheError(query)
+		return load()
+	}
+	key = fmt.Sprintf("%s|%d|%s|%s", query, version, r.clearance(ctx), key)
+
+	data, ok, err := r.backend.Get(ctx, key)
+	if err != nil {
+		logger.WithError(err).Warn("failed to read from cache")
+		metrics.ObserveCacheError(query)
+	}
+	if ok {
+		if err := decodeEntry(data, value); err == nil {
+			metrics.ObserveCacheLookup(query, true)
+			return nil
+		}
+		logger.Warn("discarding malformed cache entry")
+	}
+	metrics.ObserveCacheLookup(query, false)
+
+	if err := load(); err != nil {
+		return err
+	}
+	if data, err := encodeEntry(value); err != nil {
+		logger.WithError(err).Warn("failed to encode cache entry")
+	} else if err := r.backend.Set(ctx, key, data, r.opts.TTL); err != nil {
+		logger.WithError(err).Warn("failed to write to cache")
+		metrics.ObserveCacheError(query)
+	}
+	return nil
+}
+
+// latestUpdate returns the latest updated_at of all events, checking the
+// repository at most once per versionInterval. The check runs outside the
+// lock; while it does, other queries keep using the version it replaces
+// rather than wait for it.
+func (r *CachedRepository) latestUpdate(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	now := r.now()
+	fresh := !r.checked.IsZero() && now.Sub(r.checked) < versionInterval
+	if fresh || (r.checking && !r.checked.IsZero()) {
+		version := r.version
+		r.mu.Unlock()
+		return version, nil
+	}
+	r.checking = true
+	r.mu.Unlock()
+
+	version, err := r.EventRepository.LatestUpdate(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checking = false
+	if err != nil {
+		return 0, err
+	}
+	r.version, r.checked = version, now
+	return version, nil
+}
+
+// clearance describes what the caller may see: the sorted roles of its
+// identity, or anonymous.
+func (r *CachedRepository) clearance(ctx context.Context) string {
+	identity, ok := auth.FromContext(ctx, r.opts.ClientID)
+	if !ok {
+		return "anonymous"
+	}
+	roles := slices.Clone(identity.Roles)
+	slices.Sort(roles)
+	return strings.Join(slices.Compact(roles), ",")
+}
+
+func encodeEntry(value interface{}) ([]byte, error) {
+	if message, ok := value.(proto.Message); ok {
+		return proto.Marshal(message)
+	}
+	return json.Marshal(value)
+}
+
+func decodeEntry(data []byte, value interface{}) error {
+	if message, ok := value.(proto.Message); ok {
+		return proto.Unmarshal(data, message)
+	}
+	return json.Unmarshal(data, value)
+}
+
+func bboxKey(filter EventFilter) string {
+	if filter.Bbox == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%g,%g,%g,%g",
+		filter.Bbox.GetMinLatitude(), filter.Bbox.GetMinLongitude(),
+		filter.Bbox.GetMaxLatitude(), filter.Bbox.GetMaxLongitude())
+}
+
+// mod is the remainder of a by b, always between 0 and b-1.
+func mod(a, b int64) int64 {
+	return ((a % b) + b) % b
+}
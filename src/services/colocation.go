@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/helpers"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultCoLocatedLimit = 20
+
+// coLocatedRow is one ranked entity as returned by the co-location query.
+type coLocatedRow struct {
+	Entity        string                  `json:"entity"`
+	Edge          string                  `json:"edge"`
+	CoOccurrences int32                   `json:"co_occurrences"`
+	CoLocations   []*geovision.CoLocation `json:"co_locations"`
+}
+
+func (s *EventService) FindCoLocatedEntities(ctx context.Context, req *geovision.FindCoLocatedEntitiesRequest) (*geovision.FindCoLocatedEntitiesResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Finding co-located entities for entity with ID: %s", req.GetId())
+
+	// Validate the request
+	if err := validateEntityID(req.GetId()); err != nil {
+		logger.WithError(err).Error("invalid entity id")
+		return nil, err
+	}
+	if req.GetDistanceMeters() < 0 || req.GetTimeTolerance() < 0 {
+		logger.Error("distance and time tolerance must not be negative")
+		return nil, status.Errorf(codes.InvalidArgument, "distance and time tolerance must not be negative")
+	}
+	if req.GetMinCoOccurrences() < 0 || req.GetLimit() < 0 {
+		logger.Error("min co-occurrences and limit must not be negative")
+		return nil, status.Errorf(codes.InvalidArgument, "min co-occurrences and limit must not be negative")
+	}
+
+	minCoOccurrences := req.GetMinCoOccurrences()
+	if minCoOccurrences == 0 {
+		minCoOccurrences = 1
+	}
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = defaultCoLocatedLimit
+	}
+	if int(limit) > s.maxResults() {
+		limit = int32(s.maxResults())
+	}
+
+	// For every located event of the target, find events close in space and
+	// time and the other entities they point at, then rank those entities by
+	// the number of distinct event pairs.
+	query := `
+		LET target_events = (
+			FOR v IN 1..1 INBOUND @entity GRAPH @graph
+				FILTER IS_SAME_COLLECTION(@collection, v)
+				FILTER v.location != null
+				RETURN DISTINCT v
+		)
+
+		LET pairs = (
+			FOR te IN target_events
+				FOR oe IN @@events
+					FILTER oe._id != te._id
+					FILTER oe.happened_at >= te.happened_at - @time_tolerance && oe.happened_at <= te.happened_at + @time_tolerance
+					FILTER oe.location != null
+					LET distance = DISTANCE(te.location.latitude, te.location.longitude, oe.location.latitude, oe.location.longitude)
+					FILTER distance <= @distance
+					FOR v, e IN 1..1 OUTBOUND oe GRAPH @graph
+						FILTER NOT IS_SAME_COLLECTION(@collection, v)
+						FILTER v._id != @entity
+						RETURN {
+							entity: v._id,
+							edge: e._id,
+							co_location: {
+								target_event: te,
+								event: oe,
+								distance_meters: distance,
+								time_delta: ABS(oe.happened_at - te.happened_at)
+							}
+						}
+		)
+
+		FOR pair IN pairs
+			COLLECT entity = pair.entity INTO group = pair
+			LET co_locations = UNIQUE(group[*].co_location)
+			LET co_occurrences = LENGTH(co_locations)
+			FILTER co_occurrences >= @min_co_occurrences
+			SORT co_occurrences DESC, entity ASC
+			LIMIT @limit
+			RETURN {
+				entity: entity,
+				edge: FIRST(group).edge,
+				co_occurrences: co_occurrences,
+				co_locations: co_locations
+			}
+	`
+
+	// Execute the query
+	binds := map[string]interface{}{
+		"entity":             req.GetId(),
+		"collection":         s.Collection.Name(),
+		"@events":            s.Collection.Name(),
+		"graph":              s.DBClient.OsintGraph.Name(),
+		"distance":           req.GetDistanceMeters(),
+		"time_tolerance":     req.GetTimeTolerance(),
+		"min_co_occurrences": minCoOccurrences,
+		"limit":              limit,
+	}
+	logger.Debugf("Running query: %s with binds: %v", query, binds)
+	cursor, err := s.DBClient.DB.Query(s.queryContext(ctx), query, binds)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    req.GetId(),
+		}).Error("failed to execute AQL co-location query")
+		return nil, queryError(ctx, err)
+	}
+	defer cursor.Close()
+
+	var rows []coLocatedRow
+	for {
+		var row coLocatedRow
+		_, err := cursor.ReadDocument(ctx, &row)
+
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			if readFailed(err) {
+				logger.WithError(err).Error("failed to read co-locations")
+				return nil, queryError(ctx, err)
+			}
+			logger.WithError(err).Warn("skipping malformed co-location in stream")
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return &geovision.FindCoLocatedEntitiesResponse{}, nil
+	}
+
+	entities, err := s.relatedEntitiesByEdge(ctx, rows)
+	if err != nil {
+		logger.WithError(err).Error("failed to load co-located entities")
+		return nil, queryError(ctx, err)
+	}
+
+	// Keep the ranking of the co-location query
+	resp := &geovision.FindCoLocatedEntitiesResponse{}
+	for _, row := range rows {
+		entity, ok := entities[row.Entity]
+		if !ok {
+			continue
+		}
+		resp.Entities = append(resp.Entities, &geovision.CoLocatedEntity{
+			Entity:        entity,
+			CoOccurrences: row.CoOccurrences,
+			CoLocations:   row.CoLocations,
+		})
+	}
+
+	return resp, nil
+}
+
+// relatedEntitiesByEdge loads the entity documents of the given rows together
+// with the edge that links them to an event, keyed by entity document ID.
+func (s *EventService) relatedEntitiesByEdge(ctx context.Context, rows []coLocatedRow) (map[string]*model.RelatedEntity, error) {
+	logger := logging.GetLogger(ctx)
+
+	edges := make([]string, 0, len(rows))
+	for _, row := range rows {
+		edges = append(edges, row.Edge)
+	}
+
+	query := `
+		FOR edge_id IN @edges
+			LET e = DOCUMENT(edge_id)
+			LET v = DOCUMENT(e._to)
+			FILTER v != null
+			RETURN {
+				type: PARSE_IDENTIFIER(v._id).collection,
+				entity: v,
+				edge: e
+			}
+	`
+
+	cursor, err := s.DBClient.DB.Query(s.queryContext(ctx), query, map[string]interface{}{
+		"edges": edges,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	entities := make(map[string]*model.RelatedEntity, len(rows))
+	var rowReader helpers.DbQueryResult
+
+	for {
+		entity, err := rowReader.MapToRelatedEntity(cursor, ctx)
+
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			if readFailed(err) {
+				return nil, err
+			}
+			logger.WithError(err).Warn("skipping malformed entity in stream")
+			continue
+		}
+
+		entities[entity.GetRelation().GetTo()] = entity
+	}
+
+	return entities, nil
+}
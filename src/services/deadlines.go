@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRPCTimeout is the deadline of unary RPCs unless configured
+// otherwise.
+const DefaultRPCTimeout = 30 * time.Second
+
+// Deadlines bounds how long each RPC may run. A deadline already set by the
+// client is kept when it is sooner.
+type Deadlines struct {
+	// Default applies to unary RPCs without their own entry
+	Default time.Duration
+	// Methods holds the deadlines of single RPCs by name, such as GetEvents.
+	// Streaming RPCs only get a deadline from here.
+	Methods map[string]time.Duration
+}
+
+func (d Deadlines) timeout(fullMethod string, stream bool) time.Duration {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if timeout, ok := d.Methods[name]; ok {
+		return timeout
+	}
+	if stream {
+		return 0
+	}
+	return d.Default
+}
+
+// UnaryServerInterceptor applies the deadline of the RPC to its context.
+func (d Deadlines) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if timeout := d.timeout(info.FullMethod, false); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor does the same for streaming RPCs.
+func (d Deadlines) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if timeout := d.timeout(info.FullMethod, true); timeout > 0 {
+		ctx, cancel := context.WithTimeout(stream.Context(), timeout)
+		defer cancel()
+		stream = &deadlineStream{ServerStream: stream, ctx: ctx}
+	}
+	return handler(srv, stream)
+}
+
+type deadlineStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *deadlineStream) Context() context.Context {
+	return s.ctx
+}
+
+// queryContext sets the AQL resource limits on the context of a query. The
+// query is killed on the server once the RPC deadline passes, rather than
+// running on after the caller gave up.
+func (s *EventService) queryContext(ctx context.Context) context.Context {
+	runtime := s.queryMaxRuntime()
+	if deadline, ok := ctx.Deadline(); ok {
+		runtime = min(runtime, time.Until(deadline))
+	}
+	if runtime > 0 {
+		ctx = driver.WithQueryMaxRuntime(ctx, runtime.Seconds())
+	}
+	return driver.WithQueryMemoryLimit(ctx, s.queryMemoryLimit())
+}
+
+// queryError maps a failed query to the status returned to the caller.
+// Queries that ran out of time or memory are reported as such, so that
+// clients know to narrow them; anything else is an internal error.
+func queryError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		return status.FromContextError(ctx.Err()).Err()
+	case ctx.Err() != nil || driver.IsTimeout(err) || driver.IsArangoErrorWithErrorNum(err, driver.ErrQueryKilled):
+		return status.Errorf(codes.DeadlineExceeded, "query took too long, narrow the time range or area")
+	case driver.IsArangoErrorWithErrorNum(err, driver.ErrResourceLimit):
+		return status.Errorf(codes.ResourceExhausted, "query used too much memory, narrow the time range or area")
+	}
+	return status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+}
+
+// readFailed tells whether an error reading a cursor ends the query, as
+// opposed to a malformed document that can be skipped.
+func readFailed(err error) bool {
+	_, ok := driver.AsArangoError(err)
+	return ok || driver.IsTimeout(err) || driver.IsCanceled(err)
+}
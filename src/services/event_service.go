@@ -6,20 +6,45 @@ import (
 
 	"github.com/arangodb/go-driver"
 	"github.com/omnsight/geovision/gen/geovision/v1"
-	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/geovision/src/audit"
+	"github.com/omnsight/geovision/src/geocoding"
+	"github.com/omnsight/geovision/src/ratelimit"
 	"github.com/omnsight/omniscent-library/src/clients"
-	"github.com/omnsight/omniscent-library/src/helpers"
 	"github.com/omnsight/omniscent-library/src/logging"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// EventIndexes lists the fields of the persistent indexes on the events
+// collection.
+var EventIndexes = [][]string{
+	{"happened_at"},
+	{"updated_at"},
+}
+
 type EventService struct {
 	geovision.UnimplementedGeoServiceServer
 
+	// Repository answers the event queries
+	Repository EventRepository
+	// DBClient and Collection serve the imports, exports and analytics that
+	// still run their own AQL
 	DBClient   *clients.ArangoDBClient
 	Collection driver.Collection
+
+	// ClientID is the Keycloak client whose roles are checked for admin RPCs
+	ClientID string
+	// ReverseGeocoder fills missing admin fields of event locations, if set
+	ReverseGeocoder *geocoding.ReverseGeocoder
+	// Gazetteer resolves place names for Geocode, if set
+	Gazetteer *geocoding.Gazetteer
+	// Limits caps what a single request may ask for
+	Limits Limits
+	// RateLimiter reports the quotas of callers for GetQuota, if set
+	RateLimiter *ratelimit.Limiter
+	// AuditLog answers QueryAuditLog, if set
+	AuditLog audit.Store
 }
 
 func NewGeoService(client *clients.ArangoDBClient) (*EventService, error) {
@@ -31,11 +56,14 @@ func NewGeoService(client *clients.ArangoDBClient) (*EventService, error) {
 	}
 	logrus.Infof("✅ Initialized collection %s", collection.Name())
 
-	collection.EnsurePersistentIndex(ctx, []string{"happened_at"}, &driver.EnsurePersistentIndexOptions{
-		InBackground: true,
-	})
+	for _, fields := range EventIndexes {
+		collection.EnsurePersistentIndex(ctx, fields, &driver.EnsurePersistentIndexOptions{
+			InBackground: true,
+		})
+	}
 
 	service := &EventService{
+		Repository: NewArangoRepository(client, collection),
 		DBClient:   client,
 		Collection: collection,
 	}
@@ -46,61 +74,29 @@ func (s *EventService) GetEvents(ctx context.Context, req *geovision.GetEventsRe
 	logger := logging.GetLogger(ctx)
 	logger.Infof("Getting events")
 
-	// Validate that both start_time and end_time are provided
-	if req.GetStartTime() == 0 || req.GetEndTime() == 0 {
-		logger.Error("both start_time and end_time are required")
-		return nil, status.Errorf(codes.InvalidArgument, "both start time and end time are required")
-	}
-
-	// Validate that start_time is before end_time
-	if req.GetStartTime() > req.GetEndTime() {
-		logger.Error("start_time must be before end_time")
-		return nil, status.Errorf(codes.InvalidArgument, "start time must be before end time")
+	if err := validateEventFilters(req.GetStartTime(), req.GetEndTime(), req.GetBbox()); err != nil {
+		logger.WithError(err).Error("invalid event filters")
+		return nil, err
 	}
 
-	// Build AQL query to fetch events within the time range
-	query := `
-		LET docs = (
-            FOR doc IN @@collection
-                FILTER doc.happened_at >= @start_time && doc.happened_at <= @end_time
-                RETURN doc
-        )
-
-        LET doc_map = ZIP(docs[*]._id, docs[*]._id)
-
-        LET internal_edges = (
-            FOR start_node IN docs
-                FOR v, e IN 1..1 OUTBOUND start_node GRAPH @graph
-                FILTER HAS(doc_map, v._id)
-                RETURN e
-        )
-
-        RETURN { events: docs, relations: internal_edges }
-	`
-
-	// Execute query
-	cursor, err := s.DBClient.DB.Query(ctx, query, map[string]interface{}{
-		"start_time":  req.GetStartTime(),
-		"end_time":    req.GetEndTime(),
-		"@collection": s.Collection.Name(),
-		"graph":       s.DBClient.OsintGraph.Name(),
+	events, relations, err := s.Repository.EventsInRange(s.queryContext(ctx), EventFilter{
+		StartTime: req.GetStartTime(),
+		EndTime:   req.GetEndTime(),
+		Bbox:      req.GetBbox(),
 	})
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error": err,
-		}).Error("failed to execute AQL query for getting events")
-		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}).Error("failed to query events")
+		return nil, queryError(ctx, err)
 	}
-	defer cursor.Close()
+	resp := geovision.GetEventsResponse{Events: events, Relations: relations}
 
-	// Read all events from cursor
-	var resp geovision.GetEventsResponse
-	_, err = cursor.ReadDocument(ctx, &resp)
-
-	if driver.IsNoMoreDocuments(err) {
-		return &geovision.GetEventsResponse{}, nil
-	} else if err != nil {
-		return nil, err
+	// Fill in admin fields for events that only carry coordinates
+	if s.ReverseGeocoder != nil {
+		for _, event := range resp.Events {
+			s.ReverseGeocoder.Fill(event.GetLocation())
+		}
 	}
 
 	return &resp, nil
@@ -116,50 +112,13 @@ func (s *EventService) GetEventRelatedEntities(ctx context.Context, req *geovisi
 		return nil, status.Errorf(codes.InvalidArgument, "event key is required")
 	}
 
-	query := `
-		FOR v, e IN 1..1 OUTBOUND CONCAT(@collection, "/",@key) GRAPH @graph
-			FILTER NOT IS_SAME_COLLECTION(@collection, v)
-			LET type = PARSE_IDENTIFIER(v._id).collection
-			RETURN DISTINCT {
-				type: type,
-				entity: v,
-				edge: e
-			}
-	`
-
-	// Execute the query
-	binds := map[string]interface{}{
-		"key":        req.GetKey(),
-		"collection": s.Collection.Name(),
-		"graph":      s.DBClient.OsintGraph.Name(),
-	}
-	logger.Debugf("Running query: %s with binds: %v", query, binds)
-	cursor, err := s.DBClient.DB.Query(ctx, query, binds)
+	entities, err := s.Repository.RelatedEntities(s.queryContext(ctx), []string{s.Repository.Collection() + "/" + req.GetKey()})
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error": err,
 			"key":   req.GetKey(),
-		}).Error("failed to execute AQL graph traversal query")
-		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
-	}
-	defer cursor.Close()
-
-	// Read all related entities from cursor
-	var entities []*model.RelatedEntity
-	var rowReader helpers.DbQueryResult
-
-	for {
-		entity, err := rowReader.MapToRelatedEntity(cursor, ctx)
-
-		if driver.IsNoMoreDocuments(err) {
-			break
-		}
-		if err != nil {
-			logger.WithError(err).Warn("skipping malformed entity in stream")
-			continue
-		}
-
-		entities = append(entities, entity)
+		}).Error("failed to query related entities")
+		return nil, queryError(ctx, err)
 	}
 
 	return &geovision.GetEventRelatedEntitiesResponse{Entities: entities}, nil
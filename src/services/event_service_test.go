@@ -2,14 +2,22 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
+	"time"
 
+	"github.com/arangodb/go-driver"
 	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/geovision/src/cache"
 	"github.com/omnsight/omnibasement/gen/base/v1"
 	base_services "github.com/omnsight/omnibasement/src/services"
 	"github.com/omnsight/omniscent-library/gen/model/v1"
 	"github.com/omnsight/omniscent-library/src/clients"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -97,6 +105,23 @@ func TestGeoService(t *testing.T) {
 				t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
 			}
 		}
+
+		// Test with an inverted bbox
+		_, err = service.GetEvents(context.Background(), &geovision.GetEventsRequest{
+			StartTime: 100,
+			EndTime:   200,
+			Bbox: &geovision.BoundingBox{
+				MinLatitude: 50,
+				MaxLatitude: 40,
+			},
+		})
+		if err == nil {
+			t.Error("Expected error when bbox is inverted")
+		} else {
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
+			}
+		}
 	})
 
 	// Test GetEventRelatedEntities validation
@@ -112,6 +137,207 @@ func TestGeoService(t *testing.T) {
 		}
 	})
 
+	// Test GetEntityFootprint validation
+	t.Run("GetEntityFootprint Validation", func(t *testing.T) {
+		for _, id := range []string{"", "persons", "persons/", "/123", "persons/123/extra"} {
+			_, err := service.GetEntityFootprint(context.Background(), &geovision.GetEntityFootprintRequest{
+				Id: id,
+			})
+			if err == nil {
+				t.Errorf("Expected error for entity id %q", id)
+			} else {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
+				}
+			}
+		}
+	})
+
+	// Test FindCoLocatedEntities validation
+	t.Run("FindCoLocatedEntities Validation", func(t *testing.T) {
+		invalid := []*geovision.FindCoLocatedEntitiesRequest{
+			{},
+			{Id: "persons/123", DistanceMeters: -1},
+			{Id: "persons/123", TimeTolerance: -1},
+			{Id: "persons/123", Limit: -1},
+		}
+		for _, req := range invalid {
+			_, err := service.FindCoLocatedEntities(context.Background(), req)
+			if err == nil {
+				t.Errorf("Expected error for request %v", req)
+			} else {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
+				}
+			}
+		}
+	})
+
+	// Test GetAnomalies validation
+	t.Run("GetAnomalies Validation", func(t *testing.T) {
+		invalid := []*geovision.GetAnomaliesRequest{
+			{EndTime: 100},
+			{StartTime: 100, EndTime: 100},
+			{StartTime: 100, EndTime: 200, GeohashPrecision: 13},
+			{StartTime: 100, EndTime: 200, BaselineWindows: -1},
+			{StartTime: 100, EndTime: 200, Threshold: -1},
+		}
+		for _, req := range invalid {
+			_, err := service.GetAnomalies(context.Background(), req)
+			if err == nil {
+				t.Errorf("Expected error for request %v", req)
+			} else {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
+				}
+			}
+		}
+	})
+
+	// Test BackfillEventLocations requires an admin
+	t.Run("BackfillEventLocations Permission", func(t *testing.T) {
+		_, err := service.BackfillEventLocations(context.Background(), &geovision.BackfillEventLocationsRequest{
+			DryRun: true,
+		})
+		if err == nil {
+			t.Error("Expected error when caller is not an admin")
+		} else {
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("Expected PermissionDenied error, got %v", status.Code(err))
+			}
+		}
+	})
+
+	// Test ExportEvents validation
+	t.Run("ExportEvents Validation", func(t *testing.T) {
+		invalid := []*geovision.ExportEventsRequest{
+			{EndTime: 100},
+			{StartTime: 200, EndTime: 100},
+			{StartTime: 100, EndTime: 200, Columns: []string{"unknown"}},
+		}
+		for _, req := range invalid {
+			err := service.ExportEvents(req, &exportStream{ctx: context.Background()})
+			if err == nil {
+				t.Errorf("Expected error for request %v", req)
+			} else {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
+				}
+			}
+		}
+	})
+
+	// Test ExportGraph validates the filters and depth
+	t.Run("ExportGraph Validation", func(t *testing.T) {
+		invalid := []*geovision.ExportGraphRequest{
+			{EndTime: 100},
+			{StartTime: 100, EndTime: 200, Depth: -1},
+			{StartTime: 100, EndTime: 200, Depth: 4},
+		}
+		for _, req := range invalid {
+			_, err := service.ExportGraph(context.Background(), req)
+			if err == nil {
+				t.Errorf("Expected error for request %v", req)
+			} else {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
+				}
+			}
+		}
+	})
+
+	// Test GetKeyEntities validates the filters and limit
+	t.Run("GetKeyEntities Validation", func(t *testing.T) {
+		invalid := []*geovision.GetKeyEntitiesRequest{
+			{StartTime: 200, EndTime: 100},
+			{StartTime: 100, EndTime: 200, Limit: -1},
+			{StartTime: 100, EndTime: 200, Limit: 101},
+		}
+		for _, req := range invalid {
+			_, err := service.GetKeyEntities(context.Background(), req)
+			if err == nil {
+				t.Errorf("Expected error for request %v", req)
+			} else {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
+				}
+			}
+		}
+	})
+
+	// Test DetectCommunities validates the filters and depth
+	t.Run("DetectCommunities Validation", func(t *testing.T) {
+		invalid := []*geovision.DetectCommunitiesRequest{
+			{StartTime: 100},
+			{StartTime: 100, EndTime: 200, Depth: 5},
+		}
+		for _, req := range invalid {
+			_, err := service.DetectCommunities(context.Background(), req)
+			if err == nil {
+				t.Errorf("Expected error for request %v", req)
+			} else {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Expected InvalidArgument error, got %v", status.Code(err))
+				}
+			}
+		}
+	})
+
+	// Test ImportEvents requires an admin
+	t.Run("ImportEvents Permission", func(t *testing.T) {
+		err := service.ImportEvents(&geovision.ImportEventsRequest{
+			Format: geovision.ImportFormat_IMPORT_FORMAT_GDELT,
+			DryRun: true,
+		}, &importStream{ctx: context.Background()})
+		if err == nil {
+			t.Error("Expected error when caller is not an admin")
+		} else {
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("Expected PermissionDenied error, got %v", status.Code(err))
+			}
+		}
+	})
+
+	// Test ImportGeoJSON requires an admin
+	t.Run("ImportGeoJSON Permission", func(t *testing.T) {
+		_, err := service.ImportGeoJSON(context.Background(), &geovision.ImportGeoJSONRequest{
+			DryRun: true,
+		})
+		if err == nil {
+			t.Error("Expected error when caller is not an admin")
+		} else {
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("Expected PermissionDenied error, got %v", status.Code(err))
+			}
+		}
+	})
+
+	// Test ImportGpx requires an admin
+	t.Run("ImportGpx Permission", func(t *testing.T) {
+		_, err := service.ImportGpx(context.Background(), &geovision.ImportGpxRequest{
+			DryRun: true,
+		})
+		if err == nil {
+			t.Error("Expected error when caller is not an admin")
+		} else {
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("Expected PermissionDenied error, got %v", status.Code(err))
+			}
+		}
+	})
+
+	// Test QueryAuditLog requires an admin
+	t.Run("QueryAuditLog Permission", func(t *testing.T) {
+		_, err := service.QueryAuditLog(context.Background(), &geovision.QueryAuditLogRequest{})
+		if err == nil {
+			t.Error("Expected error when caller is not an admin")
+		} else {
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("Expected PermissionDenied error, got %v", status.Code(err))
+			}
+		}
+	})
+
 	// Test CRUD operations
 	t.Run("CRUD Operations", func(t *testing.T) {
 		// Create a person
@@ -308,6 +534,22 @@ func TestGeoService(t *testing.T) {
 			t.Logf("Found %d related entities", len(getRelatedResp.Entities))
 		}
 
+		// Test GetEntityFootprint for the organization both events point at
+		footprintResp, err := service.GetEntityFootprint(context.Background(), &geovision.GetEntityFootprintRequest{
+			Id: "organizations/" + createOrgResp.Organization.Key,
+		})
+		if err != nil {
+			t.Fatalf("Failed to get entity footprint: %v", err)
+		}
+
+		if len(footprintResp.Events) != 2 {
+			t.Errorf("Expected 2 footprint events, got %d", len(footprintResp.Events))
+		}
+
+		if footprintResp.FirstSeen != 1000 || footprintResp.LastSeen != 2000 {
+			t.Errorf("Expected footprint to span 1000..2000, got %d..%d", footprintResp.FirstSeen, footprintResp.LastSeen)
+		}
+
 		// Store the keys for later use
 		event1Key := createEvent1Resp.Event.Key
 		event2Key := createEvent2Resp.Event.Key
@@ -380,3 +622,266 @@ func TestGeoService(t *testing.T) {
 		}
 	})
 }
+
+func TestGeoServiceInMemory(t *testing.T) {
+	repository := NewMemoryRepository()
+	repository.AddEvents(
+		&model.Event{Id: "events/1", Title: "Protest", HappenedAt: 100, Location: &model.LocationData{Latitude: 48.85, Longitude: 2.35}},
+		&model.Event{Id: "events/2", Title: "Strike", HappenedAt: 150, Location: &model.LocationData{Latitude: 48.86, Longitude: 2.34}},
+		&model.Event{Id: "events/3", Title: "Riot", HappenedAt: 150, Location: &model.LocationData{Latitude: 51.5, Longitude: -0.12}},
+		&model.Event{Id: "events/4", Title: "Later", HappenedAt: 500},
+	)
+	repository.AddEntities(
+		&model.RelatedEntity{Entity: &model.RelatedEntity_Person{Person: &model.Person{Id: "persons/1", Name: "Organizer"}}},
+		&model.RelatedEntity{Entity: &model.RelatedEntity_Organization{Organization: &model.Organization{Id: "organizations/1"}}},
+	)
+	repository.AddRelations(
+		&model.Relation{Id: "relations/1", From: "events/1", To: "events/2", Name: "followed_by"},
+		&model.Relation{Id: "relations/2", From: "events/2", To: "events/3", Name: "followed_by"},
+		&model.Relation{Id: "relations/3", From: "events/1", To: "persons/1", Name: "organized_by"},
+		&model.Relation{Id: "relations/4", From: "events/2", To: "persons/1", Name: "organized_by"},
+		&model.Relation{Id: "relations/5", From: "persons/1", To: "organizations/1", Name: "member_of"},
+	)
+	service := &EventService{Repository: repository}
+
+	t.Run("GetEvents", func(t *testing.T) {
+		resp, err := service.GetEvents(context.Background(), &geovision.GetEventsRequest{
+			StartTime: 100,
+			EndTime:   200,
+			Bbox: &geovision.BoundingBox{
+				MinLatitude:  48,
+				MaxLatitude:  49,
+				MinLongitude: 2,
+				MaxLongitude: 3,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.GetEvents()) != 2 || resp.GetEvents()[0].GetTitle() != "Protest" || resp.GetEvents()[1].GetTitle() != "Strike" {
+			t.Errorf("Expected the two events in Paris, got %v", resp.GetEvents())
+		}
+		// The relation to the event outside the area is dropped
+		if len(resp.GetRelations()) != 1 || resp.GetRelations()[0].GetId() != "relations/1" {
+			t.Errorf("Expected only the relation between the returned events, got %v", resp.GetRelations())
+		}
+	})
+
+	t.Run("GetEventRelatedEntities", func(t *testing.T) {
+		resp, err := service.GetEventRelatedEntities(context.Background(), &geovision.GetEventRelatedEntitiesRequest{Key: "1"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.GetEntities()) != 1 {
+			t.Fatalf("Expected 1 related entity, got %d", len(resp.GetEntities()))
+		}
+		entity := resp.GetEntities()[0]
+		if entity.GetPerson().GetName() != "Organizer" || entity.GetRelation().GetName() != "organized_by" {
+			t.Errorf("Expected the organizer with its relation, got %v", entity)
+		}
+	})
+
+	t.Run("GetKeyEntities", func(t *testing.T) {
+		resp, err := service.GetKeyEntities(context.Background(), &geovision.GetKeyEntitiesRequest{
+			StartTime: 100,
+			EndTime:   200,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		// The organization is two relations away, beyond the default depth
+		if resp.GetNodes() != 4 || resp.GetEdges() != 4 {
+			t.Errorf("Expected 4 nodes and 4 edges, got %d and %d", resp.GetNodes(), resp.GetEdges())
+		}
+		if len(resp.GetEntities()) != 1 || resp.GetEntities()[0].GetId() != "persons/1" || resp.GetEntities()[0].GetDegree() != 2 {
+			t.Errorf("Expected the organizer ranked with degree 2, got %v", resp.GetEntities())
+		}
+	})
+
+	t.Run("GetKeyEntities Limit", func(t *testing.T) {
+		limited := &EventService{Repository: repository, Limits: Limits{MaxResults: 5}}
+		_, err := limited.GetKeyEntities(context.Background(), &geovision.GetKeyEntitiesRequest{
+			StartTime: 100,
+			EndTime:   200,
+			Limit:     10,
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument above the configured limit, got %v", status.Code(err))
+		}
+	})
+
+	t.Run("GetKeyEntities Graph Size", func(t *testing.T) {
+		limited := &EventService{Repository: repository, Limits: Limits{MaxCentralityNodes: 3}}
+		_, err := limited.GetKeyEntities(context.Background(), &geovision.GetKeyEntitiesRequest{
+			StartTime: 100,
+			EndTime:   200,
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for a graph above the node limit, got %v", status.Code(err))
+		}
+	})
+}
+
+// exportStream collects the chunks sent by ExportEvents.
+type exportStream struct {
+	grpc.ServerStreamingServer[httpbody.HttpBody]
+	ctx    context.Context
+	chunks []*httpbody.HttpBody
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportStream) Send(chunk *httpbody.HttpBody) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+// importStream collects the progress sent by ImportEvents.
+type importStream struct {
+	grpc.ServerStreamingServer[geovision.ImportEventsProgress]
+	ctx      context.Context
+	progress []*geovision.ImportEventsProgress
+}
+
+func (s *importStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *importStream) Send(progress *geovision.ImportEventsProgress) error {
+	s.progress = append(s.progress, progress)
+	return nil
+}
+
+// countingRepository counts the event queries reaching the repository.
+type countingRepository struct {
+	EventRepository
+	queries int
+}
+
+func (r *countingRepository) EventsInRange(ctx context.Context, filter EventFilter) ([]*model.Event, []*model.Relation, error) {
+	r.queries++
+	return r.EventRepository.EventsInRange(ctx, filter)
+}
+
+func TestCachedRepository(t *testing.T) {
+	memory := NewMemoryRepository()
+	memory.AddEvents(
+		&model.Event{Id: "events/1", Title: "Protest", HappenedAt: 100, UpdatedAt: 1},
+		&model.Event{Id: "events/2", Title: "Strike", HappenedAt: 150, UpdatedAt: 1},
+	)
+	source := &countingRepository{EventRepository: memory}
+	repository := NewCachedRepository(source, cache.NewLRU(10), CacheOptions{
+		TTL:      time.Minute,
+		Window:   time.Minute,
+		ClientID: "geovision",
+	})
+	now := time.Unix(1000, 0)
+	repository.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	t.Run("Hit", func(t *testing.T) {
+		events, _, err := repository.EventsInRange(ctx, EventFilter{StartTime: 100, EndTime: 120})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(events) != 1 || events[0].GetTitle() != "Protest" {
+			t.Errorf("Expected only the protest, got %v", events)
+		}
+		// Falls in the same minute, so it is cut from the cached result
+		events, _, err = repository.EventsInRange(ctx, EventFilter{StartTime: 110, EndTime: 170})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(events) != 1 || events[0].GetTitle() != "Strike" {
+			t.Errorf("Expected only the strike, got %v", events)
+		}
+		if source.queries != 1 {
+			t.Errorf("Expected 1 query to reach the repository, got %d", source.queries)
+		}
+	})
+
+	t.Run("Clearance", func(t *testing.T) {
+		payload := `{"realm_access": {"roles": ["viewer"]}}`
+		md := metadata.Pairs("authorization", "Bearer e30."+base64.RawURLEncoding.EncodeToString([]byte(payload))+".sig")
+		queries := source.queries
+		if _, _, err := repository.EventsInRange(auth.Verified(metadata.NewIncomingContext(ctx, md)), EventFilter{StartTime: 100, EndTime: 120}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if source.queries != queries+1 {
+			t.Error("Expected results not to be shared between clearances")
+		}
+	})
+
+	t.Run("Invalidation", func(t *testing.T) {
+		memory.AddEvents(&model.Event{Id: "events/3", Title: "Riot", HappenedAt: 110, UpdatedAt: 2})
+		now = now.Add(versionInterval)
+		events, _, err := repository.EventsInRange(ctx, EventFilter{StartTime: 100, EndTime: 120})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(events) != 2 {
+			t.Errorf("Expected the new event to be returned, got %v", events)
+		}
+	})
+
+	t.Run("Fresh Relations", func(t *testing.T) {
+		queries := source.queries
+		memory.AddRelations(&model.Relation{Id: "relations/1", From: "events/1", To: "events/3", Name: "escalated_to"})
+		_, relations, err := repository.EventsInRange(ctx, EventFilter{StartTime: 100, EndTime: 120})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if source.queries != queries {
+			t.Errorf("Expected the events to come from the cache, got %d queries", source.queries-queries)
+		}
+		if len(relations) != 1 || relations[0].GetId() != "relations/1" {
+			t.Errorf("Expected the new relation, got %v", relations)
+		}
+	})
+}
+
+func TestDeadlines(t *testing.T) {
+	deadlines := Deadlines{
+		Default: time.Minute,
+		Methods: map[string]time.Duration{"GetEvents": time.Second},
+	}
+	remaining := func(method string) time.Duration {
+		var timeout time.Duration
+		deadlines.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			if deadline, ok := ctx.Deadline(); ok {
+				timeout = time.Until(deadline)
+			}
+			return nil, nil
+		})
+		return timeout
+	}
+
+	t.Run("Per RPC", func(t *testing.T) {
+		if timeout := remaining("/geovision.v1.GeoService/GetEvents"); timeout <= 0 || timeout > time.Second {
+			t.Errorf("Expected the GetEvents deadline, got %v", timeout)
+		}
+		if timeout := remaining("/geovision.v1.GeoService/GetAnomalies"); timeout <= time.Second || timeout > time.Minute {
+			t.Errorf("Expected the default deadline, got %v", timeout)
+		}
+	})
+
+	t.Run("Query Errors", func(t *testing.T) {
+		ctx := context.Background()
+		if code := status.Code(queryError(ctx, driver.ArangoError{HasError: true, ErrorNum: driver.ErrQueryKilled})); code != codes.DeadlineExceeded {
+			t.Errorf("Expected DeadlineExceeded for a killed query, got %v", code)
+		}
+		if code := status.Code(queryError(ctx, driver.ArangoError{HasError: true, ErrorNum: driver.ErrResourceLimit})); code != codes.ResourceExhausted {
+			t.Errorf("Expected ResourceExhausted for a query over its memory limit, got %v", code)
+		}
+		expired, cancel := context.WithDeadline(ctx, time.Now())
+		defer cancel()
+		if code := status.Code(queryError(expired, context.DeadlineExceeded)); code != codes.DeadlineExceeded {
+			t.Errorf("Expected DeadlineExceeded past the RPC deadline, got %v", code)
+		}
+		if code := status.Code(queryError(ctx, driver.ArangoError{HasError: true, ErrorNum: driver.ErrArangoDocumentNotFound})); code != codes.Internal {
+			t.Errorf("Expected Internal for other failures, got %v", code)
+		}
+	})
+}
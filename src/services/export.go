@@ -0,0 +1,157 @@
+package services
+
+import (
+	"compress/gzip"
+	"io"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/audit"
+	"github.com/omnsight/geovision/src/export"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the size of each streamed HttpBody message.
+const exportChunkSize = 64 * 1024
+
+// exportBatchSize is the number of events fetched per cursor round trip.
+const exportBatchSize = 1000
+
+func (s *EventService) ExportEvents(req *geovision.ExportEventsRequest, stream grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	ctx := stream.Context()
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Exporting events")
+
+	if err := validateEventFilters(req.GetStartTime(), req.GetEndTime(), req.GetBbox()); err != nil {
+		logger.WithError(err).Error("invalid event filters")
+		return err
+	}
+
+	// Validate the requested columns
+	columns, err := export.SelectColumns(req.GetColumns())
+	if err != nil {
+		logger.WithError(err).Error("invalid columns")
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// Stream events straight from the cursor so large exports never sit in memory
+	query := `
+		FOR doc IN @@collection
+			FILTER doc.happened_at >= @start_time && doc.happened_at <= @end_time
+			FILTER @bbox == null || (
+				doc.location.latitude >= @bbox.min_latitude && doc.location.latitude <= @bbox.max_latitude &&
+				doc.location.longitude >= @bbox.min_longitude && doc.location.longitude <= @bbox.max_longitude
+			)
+			SORT doc.happened_at ASC
+			RETURN doc
+	`
+
+	// Execute the query
+	binds := map[string]interface{}{
+		"start_time":  req.GetStartTime(),
+		"end_time":    req.GetEndTime(),
+		"bbox":        boundingBoxBind(req.GetBbox()),
+		"@collection": s.Collection.Name(),
+	}
+	logger.Debugf("Running query: %s with binds: %v", query, binds)
+	cursor, err := s.DBClient.DB.Query(driver.WithQueryBatchSize(s.queryContext(ctx), exportBatchSize), query, binds)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to execute AQL query for exporting events")
+		return queryError(ctx, err)
+	}
+	defer cursor.Close()
+
+	contentType := "text/csv"
+	if req.GetFormat() == geovision.ExportFormat_EXPORT_FORMAT_PARQUET {
+		contentType = "application/vnd.apache.parquet"
+	}
+	if req.GetGzip() {
+		contentType = "application/gzip"
+	}
+
+	chunks := export.NewChunkWriter(exportChunkSize, func(data []byte) error {
+		return stream.Send(&httpbody.HttpBody{ContentType: contentType, Data: data})
+	})
+
+	var out io.Writer = chunks
+	var compressor *gzip.Writer
+	if req.GetGzip() {
+		compressor = gzip.NewWriter(chunks)
+		out = compressor
+	}
+
+	var rows export.RowWriter
+	if req.GetFormat() == geovision.ExportFormat_EXPORT_FORMAT_PARQUET {
+		rows = export.NewParquetWriter(out, columns)
+	} else {
+		rows = export.NewCSVWriter(out, columns)
+	}
+
+	// Write every event as one row
+	count := 0
+	var sensitivity model.Sensitivity
+	for {
+		var event model.Event
+		_, err := cursor.ReadDocument(ctx, &event)
+
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			if readFailed(err) {
+				logger.WithError(err).Error("failed to read events for export")
+				return queryError(ctx, err)
+			}
+			logger.WithError(err).Warn("skipping malformed event in stream")
+			continue
+		}
+
+		// Fill in admin fields for events that only carry coordinates
+		if s.ReverseGeocoder != nil {
+			s.ReverseGeocoder.Fill(event.GetLocation())
+		}
+
+		if err := rows.Write(&event); err != nil {
+			return exportError(logger, err)
+		}
+		count++
+		sensitivity = max(sensitivity, event.GetSensitivity())
+	}
+
+	if err := rows.Close(); err != nil {
+		return exportError(logger, err)
+	}
+	if compressor != nil {
+		if err := compressor.Close(); err != nil {
+			return exportError(logger, err)
+		}
+	}
+	if err := chunks.Flush(); err != nil {
+		return exportError(logger, err)
+	}
+
+	audit.SetReport(ctx, count, int32(sensitivity))
+	logger.Infof("Exported %d events", count)
+	return nil
+}
+
+// exportError maps a failure while writing the export to a gRPC status. Send
+// already returns a status when the client went away, so it is passed through.
+func exportError(logger logrus.FieldLogger, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	logger.WithError(err).Error("failed to write export")
+	return status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+)
+
+// LatestEvent returns the updated_at and key of the last event in the order
+// of EventsUpdatedAfter, which is where the CoT feed starts watching for
+// changes.
+func (s *EventService) LatestEvent(ctx context.Context) (int64, string, error) {
+	query := `
+		FOR doc IN @@collection
+			SORT doc.updated_at DESC, doc._key DESC
+			LIMIT 1
+			RETURN { updated_at: doc.updated_at, key: doc._key }
+	`
+
+	cursor, err := s.DBClient.DB.Query(s.queryContext(ctx), query, map[string]interface{}{
+		"@collection": s.Collection.Name(),
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	defer cursor.Close()
+
+	var latest struct {
+		UpdatedAt int64  `json:"updated_at"`
+		Key       string `json:"key"`
+	}
+	if _, err := cursor.ReadDocument(ctx, &latest); err != nil && !driver.IsNoMoreDocuments(err) {
+		return 0, "", err
+	}
+	return latest.UpdatedAt, latest.Key, nil
+}
+
+// EventsUpdatedAfter returns up to limit events coming after the given
+// updated_at and key, ordered by updated_at and then key.
+func (s *EventService) EventsUpdatedAfter(ctx context.Context, since int64, key string, limit int) ([]*model.Event, error) {
+	logger := logging.GetLogger(ctx)
+
+	query := `
+		FOR doc IN @@collection
+			FILTER doc.updated_at >= @since
+			FILTER doc.updated_at > @since OR doc._key > @key
+			SORT doc.updated_at ASC, doc._key ASC
+			LIMIT @limit
+			RETURN doc
+	`
+
+	cursor, err := s.DBClient.DB.Query(s.queryContext(ctx), query, map[string]interface{}{
+		"@collection": s.Collection.Name(),
+		"since":       since,
+		"key":         key,
+		"limit":       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var events []*model.Event
+	for {
+		var event model.Event
+		_, err := cursor.ReadDocument(ctx, &event)
+
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			if readFailed(err) {
+				return nil, err
+			}
+			logger.WithError(err).Warn("skipping malformed event in stream")
+			continue
+		}
+
+		// Fill in admin fields for events that only carry coordinates
+		if s.ReverseGeocoder != nil {
+			s.ReverseGeocoder.Fill(event.GetLocation())
+		}
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *EventService) GetEntityFootprint(ctx context.Context, req *geovision.GetEntityFootprintRequest) (*geovision.GetEntityFootprintResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Getting footprint for entity with ID: %s", req.GetId())
+
+	// Validate the entity ID
+	if err := validateEntityID(req.GetId()); err != nil {
+		logger.WithError(err).Error("invalid entity id")
+		return nil, err
+	}
+
+	// Events point at entities, so walk the edges backwards from the entity
+	query := `
+		LET events = (
+			FOR v IN 1..1 INBOUND @entity GRAPH @graph
+				FILTER IS_SAME_COLLECTION(@collection, v)
+				RETURN DISTINCT v
+		)
+
+		FOR event IN events
+			SORT event.happened_at ASC
+			RETURN event
+	`
+
+	// Execute the query
+	binds := map[string]interface{}{
+		"entity":     req.GetId(),
+		"collection": s.Collection.Name(),
+		"graph":      s.DBClient.OsintGraph.Name(),
+	}
+	logger.Debugf("Running query: %s with binds: %v", query, binds)
+	cursor, err := s.DBClient.DB.Query(s.queryContext(ctx), query, binds)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    req.GetId(),
+		}).Error("failed to execute AQL footprint query")
+		return nil, queryError(ctx, err)
+	}
+	defer cursor.Close()
+
+	// Read all events from cursor
+	var events []*model.Event
+	var points []geo.Point
+
+	for {
+		var event model.Event
+		_, err := cursor.ReadDocument(ctx, &event)
+
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			if readFailed(err) {
+				logger.WithError(err).Error("failed to read footprint events")
+				return nil, queryError(ctx, err)
+			}
+			logger.WithError(err).Warn("skipping malformed event in stream")
+			continue
+		}
+
+		events = append(events, &event)
+		if p, ok := geo.EventPoint(&event); ok {
+			points = append(points, p)
+		}
+	}
+
+	track, err := geo.Track(events)
+	if err != nil {
+		logger.WithError(err).Error("failed to build footprint track")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	resp := &geovision.GetEntityFootprintResponse{
+		Events: events,
+		Track:  track,
+		Bbox:   geo.Bounds(points),
+	}
+	if len(events) > 0 {
+		resp.FirstSeen = events[0].GetHappenedAt()
+		resp.LastSeen = events[len(events)-1].GetHappenedAt()
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultGeocodeLimit = 10
+	maxGeocodeLimit     = 50
+)
+
+func (s *EventService) Geocode(ctx context.Context, req *geovision.GeocodeRequest) (*geovision.GeocodeResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Geocoding %q", req.GetQuery())
+
+	if s.Gazetteer == nil {
+		logger.Error("gazetteer is not configured")
+		return nil, status.Errorf(codes.FailedPrecondition, "geocoding is not configured")
+	}
+
+	// Validate the request
+	if req.GetQuery() == "" {
+		logger.Error("query is required")
+		return nil, status.Errorf(codes.InvalidArgument, "query is required")
+	}
+	if req.GetLimit() < 0 {
+		logger.Error("limit must not be negative")
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultGeocodeLimit
+	}
+	if limit > maxGeocodeLimit {
+		limit = maxGeocodeLimit
+	}
+
+	places := s.Gazetteer.Search(req.GetQuery(), req.GetCountryCode(), limit)
+	return &geovision.GeocodeResponse{Places: places}, nil
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/geovision/src/ingest"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultGpxTolerance is the simplification tolerance in meters used when
+// the request leaves it unset.
+const defaultGpxTolerance = 10
+
+// gpxRelationName names the relations linking consecutive track points.
+const gpxRelationName = "followed_by"
+
+func (s *EventService) ImportGpx(ctx context.Context, req *geovision.ImportGpxRequest) (*geovision.ImportGpxResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Importing GPX events")
+
+	// Only admins may write events in bulk
+	identity, _ := auth.FromContext(ctx, s.ClientID)
+	if !identity.HasRole(auth.AdminRole) {
+		logger.Error("caller is not an admin")
+		return nil, status.Errorf(codes.PermissionDenied, "admin role is required")
+	}
+
+	tolerance := req.GetToleranceMeters()
+	if tolerance < 0 {
+		logger.Error("tolerance_meters is negative")
+		return nil, status.Errorf(codes.InvalidArgument, "tolerance must not be negative")
+	}
+	if tolerance == 0 {
+		tolerance = defaultGpxTolerance
+	}
+
+	doc, err := ingest.ParseGPX(req.GetData())
+	if err != nil {
+		logger.WithError(err).Error("invalid GPX file")
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	now := time.Now()
+	result := doc.Events(ingest.GPXOptions{
+		Tolerance:   tolerance,
+		Tags:        req.GetTags(),
+		Sensitivity: req.GetSensitivity(),
+	}, now)
+
+	resp := &geovision.ImportGpxResponse{
+		TrackPoints: int64(result.TrackPoints),
+		Waypoints:   int64(len(result.Waypoints)),
+	}
+	for _, path := range result.Paths {
+		resp.KeptPoints += int64(len(path))
+	}
+
+	if req.GetDryRun() {
+		resp.EventsCreated = resp.KeptPoints + resp.Waypoints
+		for _, path := range result.Paths {
+			resp.RelationsCreated += int64(len(path) - 1)
+		}
+		return resp, nil
+	}
+
+	fail := func(err error, msg string) (*geovision.ImportGpxResponse, error) {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error(msg)
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	var edges driver.Collection
+	if len(result.Paths) > 0 {
+		if edges, err = s.eventEdgeCollection(ctx); err != nil {
+			if _, ok := status.FromError(err); ok {
+				logger.WithError(err).Error("no edge collection links events")
+				return nil, err
+			}
+			return fail(err, "failed to open edge collection")
+		}
+	}
+
+	// Events and relations are written in one stream transaction, so that a
+	// failure partway leaves nothing behind for a retry to import again.
+	write := []string{s.Collection.Name()}
+	if edges != nil {
+		write = append(write, edges.Name())
+	}
+	tid, err := s.DBClient.DB.BeginTransaction(ctx, driver.TransactionCollections{Write: write}, nil)
+	if err != nil {
+		return fail(err, "failed to begin GPX import transaction")
+	}
+	txCtx := driver.WithTransactionID(ctx, tid)
+	abort := func(err error, msg string) (*geovision.ImportGpxResponse, error) {
+		if abortErr := s.DBClient.DB.AbortTransaction(context.WithoutCancel(ctx), tid, nil); abortErr != nil {
+			logger.WithError(abortErr).Error("failed to abort GPX import transaction")
+		}
+		return fail(err, msg)
+	}
+
+	for _, path := range result.Paths {
+		ids, err := s.createEvents(txCtx, path, now)
+		if err != nil {
+			return abort(err, "failed to insert track events")
+		}
+		resp.EventsCreated += int64(len(ids))
+
+		relations := make([]*model.Relation, 0, len(ids))
+		for i := 1; i < len(ids); i++ {
+			relations = append(relations, &model.Relation{
+				From:        ids[i-1],
+				To:          ids[i],
+				Name:        gpxRelationName,
+				Confidence:  100,
+				Sensitivity: req.GetSensitivity(),
+				CreatedAt:   now.Unix(),
+				UpdatedAt:   now.Unix(),
+			})
+		}
+		if len(relations) == 0 {
+			continue
+		}
+		_, errs, err := edges.CreateDocuments(txCtx, relations)
+		if err == nil {
+			err = errs.FirstNonNil()
+		}
+		if err != nil {
+			return abort(err, "failed to insert track relations")
+		}
+		resp.RelationsCreated += int64(len(relations))
+	}
+
+	if len(result.Waypoints) > 0 {
+		ids, err := s.createEvents(txCtx, result.Waypoints, now)
+		if err != nil {
+			return abort(err, "failed to insert waypoint events")
+		}
+		resp.EventsCreated += int64(len(ids))
+	}
+
+	if err := s.DBClient.DB.CommitTransaction(ctx, tid, nil); err != nil {
+		return abort(err, "failed to commit GPX import transaction")
+	}
+
+	logger.Infof("Imported %d events and %d relations from %d track points and %d waypoints", resp.EventsCreated, resp.RelationsCreated, resp.TrackPoints, resp.Waypoints)
+	return resp, nil
+}
+
+// createEvents inserts the events and returns their document IDs in order.
+func (s *EventService) createEvents(ctx context.Context, events []*model.Event, now time.Time) ([]string, error) {
+	for _, event := range events {
+		event.UpdatedAt = now.Unix()
+	}
+
+	metas, errs, err := s.Collection.CreateDocuments(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	if err := errs.FirstNonNil(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(metas))
+	for i, meta := range metas {
+		ids[i] = meta.ID.String()
+	}
+	return ids, nil
+}
+
+// eventEdgeCollection returns the edge collection of the OSINT graph that may
+// link two events.
+func (s *EventService) eventEdgeCollection(ctx context.Context) (driver.Collection, error) {
+	collections, constraints, err := s.DBClient.OsintGraph.EdgeCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(collections) != len(constraints) {
+		return nil, errors.New("edge collections and vertex constraints do not match")
+	}
+
+	name := s.Collection.Name()
+	for i, c := range constraints {
+		if slices.Contains(c.From, name) && slices.Contains(c.To, name) {
+			return collections[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "no edge collection of graph %s links %s", s.DBClient.OsintGraph.Name(), name)
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/audit"
+	"github.com/omnsight/geovision/src/export"
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/geovision/src/graph"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Default traversal depth of graph requests, in relations from the matching
+// events. The maximum is set by Limits.
+const defaultGraphDepth = 1
+
+// Number of entities returned by GetKeyEntities unless asked otherwise.
+const defaultKeyEntities = 10
+
+// communityRepresentatives is the number of entities shown per community.
+const communityRepresentatives = 3
+
+func (s *EventService) ExportGraph(ctx context.Context, req *geovision.ExportGraphRequest) (*httpbody.HttpBody, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Exporting event graph")
+
+	if err := validateEventFilters(req.GetStartTime(), req.GetEndTime(), req.GetBbox()); err != nil {
+		logger.WithError(err).Error("invalid event filters")
+		return nil, err
+	}
+
+	depth, err := s.graphDepth(req.GetDepth())
+	if err != nil {
+		logger.WithError(err).Error("invalid depth")
+		return nil, err
+	}
+
+	g, err := s.eventGraph(ctx, req.GetStartTime(), req.GetEndTime(), req.GetBbox(), depth)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to execute AQL query for event graph")
+		return nil, queryError(ctx, err)
+	}
+
+	var buf bytes.Buffer
+	contentType := export.GraphMLContentType
+	write := export.WriteGraphML
+	if req.GetFormat() == geovision.GraphFormat_GRAPH_FORMAT_GEXF {
+		contentType, write = export.GEXFContentType, export.WriteGEXF
+	}
+	if err := write(&buf, g); err != nil {
+		logger.WithError(err).Error("failed to write event graph")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	audit.SetReport(ctx, len(g.Nodes)+len(g.Edges), nodeSensitivity(g, nil))
+	logger.Infof("Exported %d nodes and %d edges", len(g.Nodes), len(g.Edges))
+	return &httpbody.HttpBody{ContentType: contentType, Data: buf.Bytes()}, nil
+}
+
+func (s *EventService) GetKeyEntities(ctx context.Context, req *geovision.GetKeyEntitiesRequest) (*geovision.GetKeyEntitiesResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Getting key entities")
+
+	if err := validateEventFilters(req.GetStartTime(), req.GetEndTime(), req.GetBbox()); err != nil {
+		logger.WithError(err).Error("invalid event filters")
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit < 0 || limit > s.maxResults() {
+		logger.Error("limit out of range")
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", s.maxResults())
+	}
+	if limit == 0 {
+		limit = defaultKeyEntities
+	}
+
+	g, err := s.eventGraph(ctx, req.GetStartTime(), req.GetEndTime(), req.GetBbox(), defaultGraphDepth)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to execute AQL query for event graph")
+		return nil, queryError(ctx, err)
+	}
+
+	// Betweenness runs in O(V·E), so large graphs could hold a CPU until the
+	// deadline
+	if len(g.Nodes) > s.maxCentralityNodes() {
+		logger.Errorf("graph of %d nodes is too large for centrality", len(g.Nodes))
+		return nil, status.Errorf(codes.InvalidArgument, "the graph has %d nodes, more than the %d centrality is computed for; narrow the time range or bounding box", len(g.Nodes), s.maxCentralityNodes())
+	}
+	centrality := g.Centrality()
+	entities := make([]*geovision.KeyEntity, 0, len(g.Nodes))
+	for i, node := range g.Nodes {
+		// Events are part of the graph but only entities are ranked
+		if node.Collection == s.Repository.Collection() {
+			continue
+		}
+		entities = append(entities, &geovision.KeyEntity{
+			Id:          node.ID,
+			Type:        node.Collection,
+			Label:       node.Label,
+			Degree:      int64(centrality.Degree[i]),
+			Betweenness: centrality.Betweenness[i],
+			Pagerank:    centrality.PageRank[i],
+		})
+	}
+
+	score := func(e *geovision.KeyEntity) float64 {
+		switch req.GetRankBy() {
+		case geovision.CentralityMeasure_CENTRALITY_MEASURE_DEGREE:
+			return float64(e.GetDegree())
+		case geovision.CentralityMeasure_CENTRALITY_MEASURE_BETWEENNESS:
+			return e.GetBetweenness()
+		}
+		return e.GetPagerank()
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		if a, b := score(entities[i]), score(entities[j]); a != b {
+			return a > b
+		}
+		return entities[i].GetId() < entities[j].GetId()
+	})
+	if len(entities) > limit {
+		entities = entities[:limit]
+	}
+
+	returned := make(map[string]bool, len(entities))
+	for _, entity := range entities {
+		returned[entity.GetId()] = true
+	}
+	audit.SetReport(ctx, len(entities), nodeSensitivity(g, returned))
+
+	return &geovision.GetKeyEntitiesResponse{
+		Entities: entities,
+		Nodes:    int64(len(g.Nodes)),
+		Edges:    int64(len(g.Edges)),
+	}, nil
+}
+
+func (s *EventService) DetectCommunities(ctx context.Context, req *geovision.DetectCommunitiesRequest) (*geovision.DetectCommunitiesResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Detecting communities")
+
+	if err := validateEventFilters(req.GetStartTime(), req.GetEndTime(), req.GetBbox()); err != nil {
+		logger.WithError(err).Error("invalid event filters")
+		return nil, err
+	}
+
+	depth, err := s.graphDepth(req.GetDepth())
+	if err != nil {
+		logger.WithError(err).Error("invalid depth")
+		return nil, err
+	}
+
+	g, err := s.eventGraph(ctx, req.GetStartTime(), req.GetEndTime(), req.GetBbox(), depth)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to execute AQL query for event graph")
+		return nil, queryError(ctx, err)
+	}
+
+	partition := g.Communities()
+	resp := &geovision.DetectCommunitiesResponse{Modularity: partition.Modularity}
+
+	nodes := make([]*geovision.CommunityNode, len(g.Nodes))
+	for i, node := range g.Nodes {
+		nodes[i] = &geovision.CommunityNode{
+			Id:        node.ID,
+			Type:      node.Collection,
+			Label:     node.Label,
+			Community: int32(partition.Membership[i]),
+		}
+	}
+	resp.Nodes = nodes
+
+	for id, members := range partition.Communities {
+		community := &geovision.Community{Id: int32(id), Size: int64(len(members))}
+
+		var points []geo.Point
+		for _, i := range members {
+			node := g.Nodes[i]
+			if latitude, longitude, ok := node.Location(); ok {
+				points = append(points, geo.Point{Latitude: latitude, Longitude: longitude})
+			}
+			// Members come most connected first
+			if node.Collection == s.Repository.Collection() {
+				community.Events++
+			} else if len(community.Representatives) < communityRepresentatives {
+				community.Representatives = append(community.Representatives, nodes[i])
+			}
+		}
+		community.Extent = geo.Bounds(points)
+
+		resp.Communities = append(resp.Communities, community)
+	}
+
+	audit.SetReport(ctx, len(g.Nodes), nodeSensitivity(g, nil))
+	logger.Infof("Found %d communities among %d nodes", len(resp.Communities), len(g.Nodes))
+	return resp, nil
+}
+
+// nodeSensitivity returns the highest sensitivity among the nodes with the
+// given IDs, or among all nodes when ids is nil, for the audit log.
+func nodeSensitivity(g *graph.Graph, ids map[string]bool) int32 {
+	var highest model.Sensitivity
+	for _, node := range g.Nodes {
+		if ids == nil || ids[node.ID] {
+			highest = max(highest, node.Sensitivity())
+		}
+	}
+	return int32(highest)
+}
+
+// graphDepth validates the requested traversal depth, applying the default.
+func (s *EventService) graphDepth(depth int32) (int, error) {
+	if depth < 0 || int(depth) > s.maxGraphDepth() {
+		return 0, status.Errorf(codes.InvalidArgument, "depth must be between 1 and %d", s.maxGraphDepth())
+	}
+	if depth == 0 {
+		return defaultGraphDepth, nil
+	}
+	return int(depth), nil
+}
+
+// eventGraph loads the events matching the filters and everything reachable
+// from them through at most depth relations, in either direction.
+func (s *EventService) eventGraph(ctx context.Context, startTime, endTime int64, bbox *geovision.BoundingBox, depth int) (*graph.Graph, error) {
+	vertices, relations, err := s.Repository.EventGraph(s.queryContext(ctx), EventFilter{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Bbox:      bbox,
+	}, depth)
+	if err != nil {
+		return nil, err
+	}
+	return graph.New(vertices, relations), nil
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/auth"
+	"github.com/omnsight/geovision/src/ingest"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// importFormats maps the request formats to reader formats.
+var importFormats = map[geovision.ImportFormat]string{
+	geovision.ImportFormat_IMPORT_FORMAT_ACLED: ingest.FormatACLED,
+	geovision.ImportFormat_IMPORT_FORMAT_GDELT: ingest.FormatGDELT,
+}
+
+func (s *EventService) ImportEvents(req *geovision.ImportEventsRequest, stream grpc.ServerStreamingServer[geovision.ImportEventsProgress]) error {
+	ctx := stream.Context()
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Importing events")
+
+	// Only admins may write events in bulk
+	identity, _ := auth.FromContext(ctx, s.ClientID)
+	if !identity.HasRole(auth.AdminRole) {
+		logger.Error("caller is not an admin")
+		return status.Errorf(codes.PermissionDenied, "admin role is required")
+	}
+
+	format, ok := importFormats[req.GetFormat()]
+	if !ok {
+		logger.Error("import format is required")
+		return status.Errorf(codes.InvalidArgument, "import format is required")
+	}
+
+	batchSize := int(req.GetBatchSize())
+	if batchSize < 0 || batchSize > ingest.MaxBatchSize {
+		logger.Error("batch_size out of range")
+		return status.Errorf(codes.InvalidArgument, "batch size must be between 1 and %d", ingest.MaxBatchSize)
+	}
+
+	reader, err := ingest.NewReader(format, bytes.NewReader(req.GetData()))
+	if err != nil {
+		logger.WithError(err).Error("invalid import file")
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	opts := ingest.Options{BatchSize: batchSize, DryRun: req.GetDryRun()}
+	progress, err := ingest.Import(ctx, s, reader, opts, func(p ingest.Progress) error {
+		return stream.Send(importProgress(p, false))
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to import events")
+		return status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	logger.Infof("Imported %d of %d rows (%d duplicates, %d failed)", progress.Imported, progress.Rows, progress.Duplicates, progress.Failed)
+	return stream.Send(importProgress(progress, true))
+}
+
+func importProgress(p ingest.Progress, done bool) *geovision.ImportEventsProgress {
+	msg := &geovision.ImportEventsProgress{
+		Rows:       int64(p.Rows),
+		Imported:   int64(p.Imported),
+		Duplicates: int64(p.Duplicates),
+		Failed:     int64(p.Failed),
+		Done:       done,
+	}
+	for _, e := range p.Errors {
+		msg.Errors = append(msg.Errors, &geovision.ImportRowError{Line: int64(e.Line), Message: e.Message})
+	}
+	return msg
+}
+
+// ExistingKeys returns which of the given event keys are already stored.
+func (s *EventService) ExistingKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	query := `
+		FOR doc IN @@collection
+			FILTER doc._key IN @keys
+			RETURN doc._key
+	`
+
+	cursor, err := s.DBClient.DB.Query(s.queryContext(ctx), query, map[string]interface{}{
+		"@collection": s.Collection.Name(),
+		"keys":        keys,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	existing := make(map[string]bool)
+	for {
+		var key string
+		_, err := cursor.ReadDocument(ctx, &key)
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		existing[key] = true
+	}
+	return existing, nil
+}
+
+// InsertEvents stores the events, counting those whose key is already taken
+// as duplicates and returning why any other was rejected.
+func (s *EventService) InsertEvents(ctx context.Context, events []*model.Event) (int, int, map[int]error, error) {
+	_, errs, err := s.Collection.CreateDocuments(ctx, events)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	inserted, duplicates := 0, 0
+	rejected := make(map[int]error)
+	for i, e := range errs {
+		switch {
+		case e == nil:
+			inserted++
+		case driver.IsConflict(e):
+			duplicates++
+		default:
+			logging.GetLogger(ctx).WithError(e).Warnf("failed to insert event %s", events[i].GetKey())
+			rejected[i] = e
+		}
+	}
+	return inserted, duplicates, rejected, nil
+}
+
+func (s *EventService) ImportGeoJSON(ctx context.Context, req *geovision.ImportGeoJSONRequest) (*geovision.ImportGeoJSONResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Importing GeoJSON events")
+
+	// Only admins may write events in bulk
+	identity, _ := auth.FromContext(ctx, s.ClientID)
+	if !identity.HasRole(auth.AdminRole) {
+		logger.Error("caller is not an admin")
+		return nil, status.Errorf(codes.PermissionDenied, "admin role is required")
+	}
+
+	if req.GetFeatureCollection() == nil {
+		logger.Error("feature_collection is required")
+		return nil, status.Errorf(codes.InvalidArgument, "feature collection is required")
+	}
+
+	batchSize := int(req.GetBatchSize())
+	if batchSize < 0 || batchSize > ingest.MaxBatchSize {
+		logger.Error("batch_size out of range")
+		return nil, status.Errorf(codes.InvalidArgument, "batch size must be between 1 and %d", ingest.MaxBatchSize)
+	}
+
+	data, err := req.GetFeatureCollection().MarshalJSON()
+	if err != nil {
+		logger.WithError(err).Error("failed to encode feature collection")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid feature collection")
+	}
+
+	mapping := req.GetMapping()
+	reader, err := ingest.NewGeoJSONReader(data, ingest.PropertyMapping{
+		Key:         mapping.GetKey(),
+		Title:       mapping.GetTitle(),
+		Description: mapping.GetDescription(),
+		HappenedAt:  mapping.GetHappenedAt(),
+		Tags:        mapping.GetTags(),
+		Sensitivity: mapping.GetSensitivity(),
+	})
+	if err != nil {
+		logger.WithError(err).Error("invalid feature collection")
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// Row errors are reported per batch, so collect them all for the response
+	resp := &geovision.ImportGeoJSONResponse{}
+	opts := ingest.Options{BatchSize: batchSize, DryRun: req.GetDryRun()}
+	progress, err := ingest.Import(ctx, s, reader, opts, func(p ingest.Progress) error {
+		for _, e := range p.Errors {
+			resp.Errors = append(resp.Errors, &geovision.FeatureError{Index: int32(e.Line), Message: e.Message})
+		}
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to import GeoJSON events")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	resp.Features = int64(progress.Rows)
+	resp.Imported = int64(progress.Imported)
+	resp.Duplicates = int64(progress.Duplicates)
+	resp.Failed = int64(progress.Failed)
+
+	logger.Infof("Imported %d of %d features (%d duplicates, %d failed)", resp.Imported, resp.Features, resp.Duplicates, resp.Failed)
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import "time"
+
+// Defaults of the configurable query limits.
+const (
+	DefaultMaxGraphDepth      = 3
+	DefaultMaxCentralityNodes = 5000
+	DefaultMaxResults         = 100
+	DefaultQueryMaxRuntime    = 30 * time.Second
+	DefaultQueryMemoryLimit   = 256 << 20
+)
+
+// Limits caps what a single request may ask for. Zero fields use the
+// defaults.
+type Limits struct {
+	// MaxGraphDepth is the deepest traversal graph requests may ask for
+	MaxGraphDepth int
+	// MaxCentralityNodes caps the nodes of graphs whose centrality is
+	// computed, since betweenness takes time quadratic in their size
+	MaxCentralityNodes int
+	// MaxResults caps the limit of ranked results, such as key entities and
+	// co-located entities
+	MaxResults int
+	// QueryMaxRuntime is how long ArangoDB may run a single query before
+	// killing it
+	QueryMaxRuntime time.Duration
+	// QueryMemoryLimit is the memory in bytes ArangoDB may use for a single
+	// query
+	QueryMemoryLimit int64
+}
+
+func (s *EventService) maxGraphDepth() int {
+	if s.Limits.MaxGraphDepth > 0 {
+		return s.Limits.MaxGraphDepth
+	}
+	return DefaultMaxGraphDepth
+}
+
+func (s *EventService) maxCentralityNodes() int {
+	if s.Limits.MaxCentralityNodes > 0 {
+		return s.Limits.MaxCentralityNodes
+	}
+	return DefaultMaxCentralityNodes
+}
+
+func (s *EventService) maxResults() int {
+	if s.Limits.MaxResults > 0 {
+		return s.Limits.MaxResults
+	}
+	return DefaultMaxResults
+}
+
+func (s *EventService) queryMaxRuntime() time.Duration {
+	if s.Limits.QueryMaxRuntime > 0 {
+		return s.Limits.QueryMaxRuntime
+	}
+	return DefaultQueryMaxRuntime
+}
+
+func (s *EventService) queryMemoryLimit() int64 {
+	if s.Limits.QueryMemoryLimit > 0 {
+		return s.Limits.QueryMemoryLimit
+	}
+	return DefaultQueryMemoryLimit
+}
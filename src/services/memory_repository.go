@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// MemoryRepository keeps events, entities and relations in memory and
+// answers the queries the way the AQL of ArangoRepository does. It lets
+// EventService be tested without a database.
+type MemoryRepository struct {
+	mu         sync.RWMutex
+	collection string
+	events     []*model.Event
+	entities   map[string]*model.RelatedEntity
+	relations  []*model.Relation
+}
+
+// NewMemoryRepository creates an empty repository whose events live in the
+// events collection.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		collection: "events",
+		entities:   make(map[string]*model.RelatedEntity),
+	}
+}
+
+// AddEvents stores events, whose IDs must be document handles such as
+// events/1.
+func (r *MemoryRepository) AddEvents(events ...*model.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, events...)
+}
+
+// AddEntities stores entities other than events. Their relation is ignored;
+// relations are added with AddRelations.
+func (r *MemoryRepository) AddEntities(entities ...*model.RelatedEntity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entity := range entities {
+		if id, _ := entityDocument(entity); id != "" {
+			r.entities[id] = entity
+		}
+	}
+}
+
+// AddRelations stores relations between events and entities.
+func (r *MemoryRepository) AddRelations(relations ...*model.Relation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.relations = append(r.relations, relations...)
+}
+
+func (r *MemoryRepository) Collection() string {
+	return r.collection
+}
+
+func (r *MemoryRepository) EventsInRange(ctx context.Context, filter EventFilter) ([]*model.Event, []*model.Relation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := r.matching(filter)
+	ids := make([]string, len(events))
+	for i, event := range events {
+		ids[i] = event.GetId()
+	}
+	return events, r.relationsBetween(ids), nil
+}
+
+func (r *MemoryRepository) RelationsBetween(ctx context.Context, eventIDs []string) ([]*model.Relation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.relationsBetween(eventIDs), nil
+}
+
+// relationsBetween returns the relations between the events. The caller must
+// hold the lock.
+func (r *MemoryRepository) relationsBetween(eventIDs []string) []*model.Relation {
+	ids := make(map[string]bool, len(eventIDs))
+	for _, id := range eventIDs {
+		ids[id] = true
+	}
+
+	var relations []*model.Relation
+	for _, relation := range r.relations {
+		if ids[relation.GetFrom()] && ids[relation.GetTo()] {
+			relations = append(relations, relation)
+		}
+	}
+	return relations
+}
+
+func (r *MemoryRepository) LatestUpdate(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest int64
+	for _, event := range r.events {
+		latest = max(latest, event.GetUpdatedAt())
+	}
+	return latest, nil
+}
+
+func (r *MemoryRepository) RelatedEntities(ctx context.Context, eventIDs []string) ([]*model.RelatedEntity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entities []*model.RelatedEntity
+	seen := make(map[string]bool)
+	for _, id := range eventIDs {
+		for _, relation := range r.relations {
+			if relation.GetFrom() != id || seen[relation.GetId()] {
+				continue
+			}
+			entity, ok := r.entities[relation.GetTo()]
+			if !ok {
+				continue
+			}
+			seen[relation.GetId()] = true
+			entities = append(entities, &model.RelatedEntity{Relation: relation, Entity: entity.GetEntity()})
+		}
+	}
+	return entities, nil
+}
+
+func (r *MemoryRepository) EventGraph(ctx context.Context, filter EventFilter, depth int) ([]map[string]interface{}, []*model.Relation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	documents := make(map[string]map[string]interface{})
+	for _, event := range r.events {
+		documents[event.GetId()] = document(event.GetId(), event)
+	}
+	for id, entity := range r.entities {
+		_, message := entityDocument(entity)
+		documents[id] = document(id, message)
+	}
+
+	var vertices []map[string]interface{}
+	visited := make(map[string]bool)
+	var frontier []string
+	for _, event := range r.matching(filter) {
+		visited[event.GetId()] = true
+		frontier = append(frontier, event.GetId())
+		vertices = append(vertices, documents[event.GetId()])
+	}
+
+	// Walk the relations in either direction, one level at a time, skipping
+	// those whose other end is missing like the traversal does
+	var relations []*model.Relation
+	traversed := make(map[string]bool)
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		current := make(map[string]bool, len(frontier))
+		for _, id := range frontier {
+			current[id] = true
+		}
+		frontier = nil
+
+		for _, relation := range r.relations {
+			from, to := relation.GetFrom(), relation.GetTo()
+			if !current[from] && !current[to] {
+				continue
+			}
+			if documents[from] == nil || documents[to] == nil {
+				continue
+			}
+			if !traversed[relation.GetId()] {
+				traversed[relation.GetId()] = true
+				relations = append(relations, relation)
+			}
+			for _, id := range []string{from, to} {
+				if !visited[id] {
+					visited[id] = true
+					frontier = append(frontier, id)
+					vertices = append(vertices, documents[id])
+				}
+			}
+		}
+	}
+	return vertices, relations, nil
+}
+
+// matching returns the events selected by the filter. The caller holds the
+// lock.
+func (r *MemoryRepository) matching(filter EventFilter) []*model.Event {
+	var events []*model.Event
+	for _, event := range r.events {
+		if event.GetHappenedAt() < filter.StartTime || event.GetHappenedAt() > filter.EndTime {
+			continue
+		}
+		if filter.Bbox != nil && !inBoundingBox(event.GetLocation(), filter.Bbox) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func inBoundingBox(location *model.LocationData, bbox *geovision.BoundingBox) bool {
+	if location == nil {
+		return false
+	}
+	latitude, longitude := float64(location.GetLatitude()), float64(location.GetLongitude())
+	return latitude >= bbox.GetMinLatitude() && latitude <= bbox.GetMaxLatitude() &&
+		longitude >= bbox.GetMinLongitude() && longitude <= bbox.GetMaxLongitude()
+}
+
+// entityDocument returns the document handle and message of an entity.
+func entityDocument(entity *model.RelatedEntity) (string, interface{}) {
+	switch {
+	case entity.GetPerson() != nil:
+		return entity.GetPerson().GetId(), entity.GetPerson()
+	case entity.GetOrganization() != nil:
+		return entity.GetOrganization().GetId(), entity.GetOrganization()
+	case entity.GetSource() != nil:
+		return entity.GetSource().GetId(), entity.GetSource()
+	case entity.GetWebsite() != nil:
+		return entity.GetWebsite().GetId(), entity.GetWebsite()
+	}
+	return "", nil
+}
+
+// document converts a message to the raw document ArangoDB would return.
+func document(id string, message interface{}) map[string]interface{} {
+	doc := make(map[string]interface{})
+	if data, err := json.Marshal(message); err == nil {
+		json.Unmarshal(data, &doc)
+	}
+	doc["_id"] = id
+	if _, key, ok := strings.Cut(id, "/"); ok {
+		doc["_key"] = key
+	}
+	return doc
+}
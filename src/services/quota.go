@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+)
+
+func (s *EventService) GetQuota(ctx context.Context, req *geovision.GetQuotaRequest) (*geovision.GetQuotaResponse, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Info("Getting quota")
+
+	if s.RateLimiter == nil {
+		return &geovision.GetQuotaResponse{}, nil
+	}
+
+	var methods []string
+	for _, method := range geovision.GeoService_ServiceDesc.Methods {
+		methods = append(methods, method.MethodName)
+	}
+	for _, stream := range geovision.GeoService_ServiceDesc.Streams {
+		methods = append(methods, stream.StreamName)
+	}
+	slices.Sort(methods)
+
+	caller, usage := s.RateLimiter.Usage(ctx, methods)
+	resp := &geovision.GetQuotaResponse{Caller: caller}
+	for _, u := range usage {
+		resp.Quotas = append(resp.Quotas, &geovision.Quota{
+			Rpc:       u.Method,
+			Rate:      u.Limit.Rate,
+			Burst:     int32(u.Limit.Burst),
+			Remaining: u.Remaining,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/clients"
+	"github.com/omnsight/omniscent-library/src/helpers"
+	"github.com/omnsight/omniscent-library/src/logging"
+)
+
+// EventFilter selects the events that happened within a time window and,
+// optionally, an area.
+type EventFilter struct {
+	StartTime int64
+	EndTime   int64
+	Bbox      *geovision.BoundingBox
+}
+
+// EventRepository is the storage behind the event queries of EventService.
+// Filters are validated by the service before they get here.
+type EventRepository interface {
+	// Collection is the name of the events collection, which prefixes the
+	// document handles of events.
+	Collection() string
+
+	// EventsInRange returns the events matching the filter and the relations
+	// between them.
+	EventsInRange(ctx context.Context, filter EventFilter) ([]*model.Event, []*model.Relation, error)
+
+	// RelationsBetween returns the relations from one of the given events to
+	// another.
+	RelationsBetween(ctx context.Context, eventIDs []string) ([]*model.Relation, error)
+
+	// RelatedEntities returns the entities other than events that the given
+	// events point at, each with the relation leading to it.
+	RelatedEntities(ctx context.Context, eventIDs []string) ([]*model.RelatedEntity, error)
+
+	// LatestUpdate returns the highest updated_at of all events, which
+	// changes whenever an event is stored or updated.
+	LatestUpdate(ctx context.Context) (int64, error)
+
+	// EventGraph returns the raw documents of the events matching the filter
+	// and of everything reachable from them within depth relations in either
+	// direction, along with the relations traversed.
+	EventGraph(ctx context.Context, filter EventFilter, depth int) ([]map[string]interface{}, []*model.Relation, error)
+}
+
+// ArangoRepository runs the event queries as AQL against the OSINT graph.
+type ArangoRepository struct {
+	DB         driver.Database
+	Graph      driver.Graph
+	collection string
+}
+
+// NewArangoRepository creates a repository over the events collection of
+// the given client.
+func NewArangoRepository(client *clients.ArangoDBClient, collection driver.Collection) *ArangoRepository {
+	return &ArangoRepository{
+		DB:         client.DB,
+		Graph:      client.OsintGraph,
+		collection: collection.Name(),
+	}
+}
+
+func (r *ArangoRepository) Collection() string {
+	return r.collection
+}
+
+func (r *ArangoRepository) EventsInRange(ctx context.Context, filter EventFilter) ([]*model.Event, []*model.Relation, error) {
+	query := `
+		LET docs = (
+            FOR doc IN @@collection
+                FILTER doc.happened_at >= @start_time && doc.happened_at <= @end_time
+                FILTER @bbox == null || (
+                    doc.location.latitude >= @bbox.min_latitude && doc.location.latitude <= @bbox.max_latitude &&
+                    doc.location.longitude >= @bbox.min_longitude && doc.location.longitude <= @bbox.max_longitude
+                )
+                RETURN doc
+        )
+
+        LET doc_map = ZIP(docs[*]._id, docs[*]._id)
+
+        LET internal_edges = (
+            FOR start_node IN docs
+                FOR v, e IN 1..1 OUTBOUND start_node GRAPH @graph
+                FILTER HAS(doc_map, v._id)
+                RETURN e
+        )
+
+        RETURN { events: docs, relations: internal_edges }
+	`
+
+	cursor, err := r.DB.Query(ctx, query, map[string]interface{}{
+		"start_time":  filter.StartTime,
+		"end_time":    filter.EndTime,
+		"bbox":        boundingBoxBind(filter.Bbox),
+		"@collection": r.collection,
+		"graph":       r.Graph.Name(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close()
+
+	var result geovision.GetEventsResponse
+	if _, err := cursor.ReadDocument(ctx, &result); err != nil && !driver.IsNoMoreDocuments(err) {
+		return nil, nil, err
+	}
+	return result.Events, result.Relations, nil
+}
+
+func (r *ArangoRepository) RelationsBetween(ctx context.Context, eventIDs []string) ([]*model.Relation, error) {
+	if len(eventIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		LET doc_map = ZIP(@ids, @ids)
+
+		FOR id IN @ids
+			FOR v, e IN 1..1 OUTBOUND id GRAPH @graph
+				FILTER HAS(doc_map, v._id)
+				RETURN e
+	`
+
+	cursor, err := r.DB.Query(ctx, query, map[string]interface{}{
+		"ids":   eventIDs,
+		"graph": r.Graph.Name(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var relations []*model.Relation
+	for {
+		var relation model.Relation
+		_, err := cursor.ReadDocument(ctx, &relation)
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		relations = append(relations, &relation)
+	}
+	return relations, nil
+}
+
+func (r *ArangoRepository) LatestUpdate(ctx context.Context) (int64, error) {
+	query := `
+		FOR doc IN @@collection
+			SORT doc.updated_at DESC
+			LIMIT 1
+			RETURN doc.updated_at
+	`
+
+	cursor, err := r.DB.Query(ctx, query, map[string]interface{}{
+		"@collection": r.collection,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	var latest int64
+	if _, err := cursor.ReadDocument(ctx, &latest); err != nil && !driver.IsNoMoreDocuments(err) {
+		return 0, err
+	}
+	return latest, nil
+}
+
+func (r *ArangoRepository) RelatedEntities(ctx context.Context, eventIDs []string) ([]*model.RelatedEntity, error) {
+	logger := logging.GetLogger(ctx)
+	if len(eventIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		FOR id IN @events
+			FOR v, e IN 1..1 OUTBOUND id GRAPH @graph
+				FILTER NOT IS_SAME_COLLECTION(@collection, v)
+				RETURN DISTINCT {
+					type: PARSE_IDENTIFIER(v._id).collection,
+					entity: v,
+					edge: e
+				}
+	`
+
+	binds := map[string]interface{}{
+		"events":     eventIDs,
+		"collection": r.collection,
+		"graph":      r.Graph.Name(),
+	}
+	logger.Debugf("Running query: %s with binds: %v", query, binds)
+	cursor, err := r.DB.Query(ctx, query, binds)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var entities []*model.RelatedEntity
+	var rowReader helpers.DbQueryResult
+
+	for {
+		entity, err := rowReader.MapToRelatedEntity(cursor, ctx)
+
+		if driver.IsNoMoreDocuments(err) {
+			break
+		}
+		if err != nil {
+			if readFailed(err) {
+				return nil, err
+			}
+			logger.WithError(err).Warn("skipping malformed entity in stream")
+			continue
+		}
+
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+func (r *ArangoRepository) EventGraph(ctx context.Context, filter EventFilter, depth int) ([]map[string]interface{}, []*model.Relation, error) {
+	logger := logging.GetLogger(ctx)
+
+	query := `
+		LET docs = (
+			FOR doc IN @@collection
+				FILTER doc.happened_at >= @start_time && doc.happened_at <= @end_time
+				FILTER @bbox == null || (
+					doc.location.latitude >= @bbox.min_latitude && doc.location.latitude <= @bbox.max_latitude &&
+					doc.location.longitude >= @bbox.min_longitude && doc.location.longitude <= @bbox.max_longitude
+				)
+				RETURN doc
+		)
+
+		LET steps = (
+			FOR start IN docs
+				FOR v, e IN 1..@depth ANY start GRAPH @graph
+					FILTER v != null
+					RETURN { vertex: v, edge: e }
+		)
+
+		RETURN {
+			vertices: UNION_DISTINCT(docs, steps[*].vertex),
+			relations: UNIQUE(steps[*].edge)
+		}
+	`
+
+	binds := map[string]interface{}{
+		"start_time":  filter.StartTime,
+		"end_time":    filter.EndTime,
+		"bbox":        boundingBoxBind(filter.Bbox),
+		"depth":       depth,
+		"@collection": r.collection,
+		"graph":       r.Graph.Name(),
+	}
+	logger.Debugf("Running query: %s with binds: %v", query, binds)
+	cursor, err := r.DB.Query(ctx, query, binds)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close()
+
+	var result struct {
+		Vertices  []map[string]interface{} `json:"vertices"`
+		Relations []*model.Relation        `json:"relations"`
+	}
+	if _, err := cursor.ReadDocument(ctx, &result); err != nil && !driver.IsNoMoreDocuments(err) {
+		return nil, nil, err
+	}
+	return result.Vertices, result.Relations, nil
+}
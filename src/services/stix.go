@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/audit"
+	"github.com/omnsight/geovision/src/export"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/omnsight/omniscent-library/src/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *EventService) ExportStix(ctx context.Context, req *geovision.ExportStixRequest) (*httpbody.HttpBody, error) {
+	logger := logging.GetLogger(ctx)
+	logger.Infof("Exporting events as STIX")
+
+	// GetEvents validates the filters and loads the events with their relations
+	events, err := s.GetEvents(ctx, &geovision.GetEventsRequest{
+		StartTime: req.GetStartTime(),
+		EndTime:   req.GetEndTime(),
+		Bbox:      req.GetBbox(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	related, err := s.relatedEntitiesOfEvents(ctx, events.GetEvents())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to execute AQL query for related entities")
+		return nil, queryError(ctx, err)
+	}
+
+	bundle, err := export.BuildStixBundle(events, related)
+	if err != nil {
+		logger.WithError(err).Error("failed to build STIX bundle")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	// The audit log cannot see into the bundle
+	eventCount, eventSensitivity := audit.Inspect(events)
+	relatedCount, relatedSensitivity := audit.Inspect(&geovision.GetEventRelatedEntitiesResponse{Entities: related})
+	audit.SetReport(ctx, eventCount+relatedCount, max(eventSensitivity, relatedSensitivity))
+
+	return &httpbody.HttpBody{ContentType: export.StixContentType, Data: bundle}, nil
+}
+
+// relatedEntitiesOfEvents returns the entities every event points at, like
+// GetEventRelatedEntities does for a single event.
+func (s *EventService) relatedEntitiesOfEvents(ctx context.Context, events []*model.Event) ([]*model.RelatedEntity, error) {
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.GetId())
+	}
+
+	return s.Repository.RelatedEntities(s.queryContext(ctx), ids)
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateEntityID checks that id is an ArangoDB document ID of the form
+// "<collection>/<key>".
+func validateEntityID(id string) error {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return status.Errorf(codes.InvalidArgument, "entity id must have the form <collection>/<key>")
+	}
+	return nil
+}
+
+// validateBoundingBox checks an optional area filter. A nil box is valid.
+func validateBoundingBox(bbox *geovision.BoundingBox) error {
+	if bbox == nil {
+		return nil
+	}
+	if bbox.GetMinLatitude() < -90 || bbox.GetMaxLatitude() > 90 ||
+		bbox.GetMinLongitude() < -180 || bbox.GetMaxLongitude() > 180 {
+		return status.Errorf(codes.InvalidArgument, "bbox must be within -90..90 latitude and -180..180 longitude")
+	}
+	if bbox.GetMinLatitude() > bbox.GetMaxLatitude() || bbox.GetMinLongitude() > bbox.GetMaxLongitude() {
+		return status.Errorf(codes.InvalidArgument, "bbox minimum must not exceed its maximum")
+	}
+	return nil
+}
+
+// boundingBoxBind converts an optional area filter to an AQL bind value.
+func boundingBoxBind(bbox *geovision.BoundingBox) interface{} {
+	if bbox == nil {
+		return nil
+	}
+	return map[string]float64{
+		"min_latitude":  bbox.GetMinLatitude(),
+		"min_longitude": bbox.GetMinLongitude(),
+		"max_latitude":  bbox.GetMaxLatitude(),
+		"max_longitude": bbox.GetMaxLongitude(),
+	}
+}
+
+// validateEventFilters checks the time window and optional area shared by the
+// requests that select events like GetEvents does.
+func validateEventFilters(startTime, endTime int64, bbox *geovision.BoundingBox) error {
+	if startTime == 0 || endTime == 0 {
+		return status.Errorf(codes.InvalidArgument, "both start time and end time are required")
+	}
+	if startTime > endTime {
+		return status.Errorf(codes.InvalidArgument, "start time must be before end time")
+	}
+	return validateBoundingBox(bbox)
+}
@@ -0,0 +1,413 @@
+package tak
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/audit"
+	"github.com/omnsight/geovision/src/export"
+	"github.com/omnsight/geovision/src/geo"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPollInterval is how often the feed looks for new events.
+const DefaultPollInterval = 5 * time.Second
+
+// pollLimit bounds the number of events fetched per poll.
+const pollLimit = 1000
+
+// clientBuffer is the number of messages queued per TCP client before it is
+// considered too slow and dropped.
+const clientBuffer = 256
+
+// handshakeTimeout bounds the TLS handshake of TCP clients.
+const handshakeTimeout = 10 * time.Second
+
+// FeedRPC names the feed in the audit log.
+const FeedRPC = "CoTFeed"
+
+// EventSource provides the events pushed by the feed.
+type EventSource interface {
+	// LatestEvent returns the updated_at and key of the last event in the
+	// order of EventsUpdatedAfter.
+	LatestEvent(ctx context.Context) (updatedAt int64, key string, err error)
+	// EventsUpdatedAfter returns up to limit events coming after the given
+	// updated_at and key, ordered by updated_at and then key.
+	EventsUpdatedAfter(ctx context.Context, since int64, key string, limit int) ([]*model.Event, error)
+}
+
+// Auditor records what each client of the feed was sent, such as an
+// *audit.Recorder.
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
+// Options restrict what the feed pushes and to whom.
+type Options struct {
+	// MaxSensitivity is the highest sensitivity of the events pushed
+	MaxSensitivity model.Sensitivity
+	// Bbox, if set, limits the feed to events inside it
+	Bbox *geovision.BoundingBox
+	// MaxAge, if positive, skips events that happened longer ago
+	MaxAge time.Duration
+	// Allowed, if set, are the networks TCP clients may connect from.
+	// Without it, clients must be authenticated by the TLS listener.
+	Allowed []*net.IPNet
+	// Audit, if set, records the events pushed to each client
+	Audit Auditor
+}
+
+// Feed pushes newly stored or updated events to connected TAK clients as
+// Cursor-on-Target messages, over TCP streams and UDP datagrams.
+type Feed struct {
+	source   EventSource
+	interval time.Duration
+	stale    time.Duration
+	limit    int
+	opts     Options
+	now      func() time.Time
+
+	mu              sync.Mutex
+	tcpClients      map[net.Conn]*tcpClient
+	udpConn         net.PacketConn
+	udpDestinations []net.Addr
+}
+
+type tcpClient struct {
+	queue chan []byte
+	// subject is the common name of the client certificate, if any
+	subject string
+}
+
+// NewFeed creates a feed polling the source at the given interval.
+func NewFeed(source EventSource, interval, stale time.Duration, opts Options) *Feed {
+	return &Feed{
+		source:     source,
+		interval:   interval,
+		stale:      stale,
+		limit:      pollLimit,
+		opts:       opts,
+		now:        time.Now,
+		tcpClients: make(map[net.Conn]*tcpClient),
+	}
+}
+
+// cursor is the updated_at and key of the last event seen by the feed.
+type cursor struct {
+	updatedAt int64
+	key       string
+}
+
+// Run polls for new events until the context is done. Only events stored
+// after the feed started are pushed. The feed starts after the last stored
+// event, which is looked up again on every tick until the database answers.
+func (f *Feed) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	var next *cursor
+	for {
+		if next == nil {
+			updatedAt, key, err := f.source.LatestEvent(ctx)
+			if err != nil {
+				logrus.WithError(err).Warn("failed to find the start of the CoT feed, retrying")
+			} else {
+				next = &cursor{updatedAt: updatedAt, key: key}
+			}
+		} else {
+			f.poll(ctx, next)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll pushes the events after the cursor a page at a time, moving the
+// cursor past them. Paging by key as well as updated_at keeps the feed going
+// when more events than fit in a page share one updated_at, as bulk imports
+// produce.
+func (f *Feed) poll(ctx context.Context, next *cursor) {
+	for ctx.Err() == nil {
+		events, err := f.source.EventsUpdatedAfter(ctx, next.updatedAt, next.key, f.limit)
+		if err != nil {
+			logrus.WithError(err).Warn("failed to poll events for CoT feed")
+			return
+		}
+
+		if len(events) > 0 {
+			last := events[len(events)-1]
+			next.updatedAt, next.key = last.GetUpdatedAt(), last.GetKey()
+		}
+		f.Publish(events)
+		if len(events) < f.limit {
+			return
+		}
+	}
+}
+
+// Publish sends the events that pass the filters of the feed to every
+// client, and records what each client was sent.
+func (f *Feed) Publish(events []*model.Event) {
+	now := f.now()
+	var messages [][]byte
+	var sensitivity model.Sensitivity
+	for _, event := range events {
+		if !f.accepts(event, now) {
+			continue
+		}
+		cot, ok := export.NewCotEvent(event, now, f.stale)
+		if !ok {
+			continue
+		}
+		data, err := export.MarshalCot(cot)
+		if err != nil {
+			logrus.WithError(err).Error("failed to encode CoT message")
+			continue
+		}
+		messages = append(messages, data)
+		sensitivity = max(sensitivity, event.GetSensitivity())
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for conn, client := range f.tcpClients {
+		sent := 0
+		for _, data := range messages {
+			select {
+			case client.queue <- data:
+				sent++
+				continue
+			default:
+				logrus.WithField("client", conn.RemoteAddr().String()).Warn("dropping slow CoT client")
+				f.dropTCP(conn)
+			}
+			break
+		}
+		f.audit(now, client.subject, "tcp", conn.RemoteAddr().String(), sent, sensitivity)
+	}
+
+	for _, addr := range f.udpDestinations {
+		sent := 0
+		for _, data := range messages {
+			if _, err := f.udpConn.WriteTo(data, addr); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"error":       err,
+					"destination": addr.String(),
+				}).Warn("failed to send CoT datagram")
+				break
+			}
+			sent++
+		}
+		f.audit(now, "", "udp", addr.String(), sent, sensitivity)
+	}
+}
+
+// accepts tells whether an event passes the sensitivity, area and age
+// filters of the feed.
+func (f *Feed) accepts(event *model.Event, now time.Time) bool {
+	if event.GetSensitivity() > f.opts.MaxSensitivity {
+		return false
+	}
+	if f.opts.MaxAge > 0 && event.GetHappenedAt() < now.Add(-f.opts.MaxAge).Unix() {
+		return false
+	}
+	if f.opts.Bbox != nil {
+		p, ok := geo.EventPoint(event)
+		if !ok || !geo.InBounds(f.opts.Bbox, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// audit records what a client was sent. The sensitivity is the highest among
+// the events of the batch. Clients are authenticated by their certificate,
+// whose common name is the subject.
+func (f *Feed) audit(now time.Time, subject, transport, client string, sent int, sensitivity model.Sensitivity) {
+	if f.opts.Audit == nil || sent == 0 {
+		return
+	}
+	f.opts.Audit.Record(audit.Entry{
+		Time:    now.Unix(),
+		Subject: subject,
+		RPC:     FeedRPC,
+		Filters: map[string]interface{}{
+			"transport": transport,
+			"client":    client,
+		},
+		Results:       sent,
+		Sensitivity:   int32(sensitivity),
+		Code:          "OK",
+		Authenticated: subject != "",
+	})
+}
+
+// ServeTCP accepts TAK clients on the listener until the context is done.
+// Clients outside the allowed networks are refused; a TLS listener requiring
+// client certificates authenticates the others.
+func (f *Feed) ServeTCP(ctx context.Context, lis net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		lis.Close()
+	}()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go f.accept(conn)
+	}
+}
+
+func (f *Feed) accept(conn net.Conn) {
+	client := conn.RemoteAddr().String()
+	if !f.allowed(conn.RemoteAddr()) {
+		logrus.WithField("client", client).Warn("refusing CoT client outside the allowed networks")
+		conn.Close()
+		return
+	}
+
+	var subject string
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		tlsConn.SetDeadline(time.Now().Add(handshakeTimeout))
+		if err := tlsConn.Handshake(); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"error":  err,
+				"client": client,
+			}).Warn("CoT client failed the TLS handshake")
+			conn.Close()
+			return
+		}
+		tlsConn.SetDeadline(time.Time{})
+		if certs := tlsConn.ConnectionState().PeerCertificates; len(certs) > 0 {
+			subject = certs[0].Subject.CommonName
+		}
+	}
+	f.addTCP(conn, subject)
+}
+
+func (f *Feed) allowed(addr net.Addr) bool {
+	if len(f.opts.Allowed) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	for _, network := range f.opts.Allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Feed) addTCP(conn net.Conn, subject string) {
+	queue := make(chan []byte, clientBuffer)
+
+	f.mu.Lock()
+	f.tcpClients[conn] = &tcpClient{queue: queue, subject: subject}
+	f.mu.Unlock()
+	logrus.WithFields(logrus.Fields{
+		"client":  conn.RemoteAddr().String(),
+		"subject": subject,
+	}).Info("CoT client connected")
+
+	// Clients send their own position reports, which are ignored; reading
+	// them tells us when the client goes away
+	go func() {
+		io.Copy(io.Discard, conn)
+		f.mu.Lock()
+		f.dropTCP(conn)
+		f.mu.Unlock()
+	}()
+
+	go func() {
+		for data := range queue {
+			conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if _, err := conn.Write(data); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}()
+}
+
+// dropTCP disconnects a client. The caller must hold the lock.
+func (f *Feed) dropTCP(conn net.Conn) {
+	client, ok := f.tcpClients[conn]
+	if !ok {
+		return
+	}
+	delete(f.tcpClients, conn)
+	close(client.queue)
+	conn.Close()
+	logrus.WithField("client", conn.RemoteAddr().String()).Info("CoT client disconnected")
+}
+
+// ServeUDP sends the events from conn to the configured destinations until
+// the context is done. Nothing received on conn subscribes anyone, so the
+// feed cannot be pointed at addresses that did not ask for it.
+func (f *Feed) ServeUDP(ctx context.Context, conn net.PacketConn, destinations []net.Addr) error {
+	f.mu.Lock()
+	f.udpConn = conn
+	f.udpDestinations = destinations
+	f.mu.Unlock()
+
+	<-ctx.Done()
+
+	f.mu.Lock()
+	f.udpConn = nil
+	f.udpDestinations = nil
+	f.mu.Unlock()
+	return conn.Close()
+}
+
+// TLSConfig loads the certificate served to TCP clients. With a client CA,
+// clients must present a certificate signed by it.
+func TLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
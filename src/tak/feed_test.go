@@ -0,0 +1,207 @@
+package tak
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omnsight/geovision/gen/geovision/v1"
+	"github.com/omnsight/geovision/src/audit"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+)
+
+// fakeSource serves events from memory. The first failures calls to
+// LatestEvent fail, as while the database is starting.
+type fakeSource struct {
+	mu       sync.Mutex
+	events   []*model.Event
+	failures int
+}
+
+func (s *fakeSource) add(event *model.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *fakeSource) LatestEvent(ctx context.Context) (int64, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return 0, "", errors.New("database unavailable")
+	}
+	var latest *model.Event
+	for _, e := range s.events {
+		if latest == nil || e.GetUpdatedAt() > latest.GetUpdatedAt() ||
+			(e.GetUpdatedAt() == latest.GetUpdatedAt() && e.GetKey() > latest.GetKey()) {
+			latest = e
+		}
+	}
+	return latest.GetUpdatedAt(), latest.GetKey(), nil
+}
+
+func (s *fakeSource) EventsUpdatedAfter(ctx context.Context, since int64, key string, limit int) ([]*model.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []*model.Event
+	for _, e := range s.events {
+		if e.GetUpdatedAt() > since || (e.GetUpdatedAt() == since && e.GetKey() > key) {
+			events = append(events, e)
+		}
+	}
+	slices.SortFunc(events, func(a, b *model.Event) int {
+		return cmp.Or(cmp.Compare(a.GetUpdatedAt(), b.GetUpdatedAt()), cmp.Compare(a.GetKey(), b.GetKey()))
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func TestFeed(t *testing.T) {
+	source := &fakeSource{failures: 2}
+	source.add(&model.Event{Id: "events/1", Key: "1", Title: "Old", UpdatedAt: 10, Location: &model.LocationData{Latitude: 1, Longitude: 1}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feed := NewFeed(source, 10*time.Millisecond, time.Hour, Options{})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go feed.ServeTCP(ctx, lis)
+	go feed.Run(ctx)
+
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	// Give the feed time to register the client and, after the failed
+	// attempts, record the start point
+	time.Sleep(100 * time.Millisecond)
+	// Stored in the same second the feed started
+	source.add(&model.Event{Id: "events/2", Key: "2", Title: "New", UpdatedAt: 10, Location: &model.LocationData{Latitude: 2, Longitude: 3}})
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	reader := bufio.NewReader(conn)
+	var received strings.Builder
+	for !strings.Contains(received.String(), "</event>") {
+		line, err := reader.ReadString('>')
+		if err != nil {
+			t.Fatalf("Failed to read CoT message: %v (got %q)", err, received.String())
+		}
+		received.WriteString(line)
+	}
+
+	if !strings.Contains(received.String(), `callsign="New"`) {
+		t.Errorf("Expected the new event, got %s", received.String())
+	}
+	if strings.Contains(received.String(), `callsign="Old"`) {
+		t.Errorf("Expected events from before the feed started to be skipped, got %s", received.String())
+	}
+}
+
+func TestFeedPaging(t *testing.T) {
+	source := &fakeSource{}
+	feed := NewFeed(source, time.Hour, time.Hour, Options{})
+	feed.limit = 2
+
+	// More events than fit in a page share one updated_at, as after a bulk
+	// import
+	for i := 0; i < 5; i++ {
+		source.add(&model.Event{Id: fmt.Sprintf("events/%d", i), Key: fmt.Sprint(i), UpdatedAt: 20})
+	}
+	source.add(&model.Event{Id: "events/later", Key: "later", UpdatedAt: 30})
+
+	next := cursor{updatedAt: 10}
+	feed.poll(context.Background(), &next)
+	if next.updatedAt != 30 || next.key != "later" {
+		t.Errorf("Expected the cursor past every event, got %+v", next)
+	}
+}
+
+type fakeAuditor struct {
+	entries []audit.Entry
+}
+
+func (a *fakeAuditor) Record(entry audit.Entry) {
+	a.entries = append(a.entries, entry)
+}
+
+func TestFeedFilters(t *testing.T) {
+	auditor := &fakeAuditor{}
+	_, allowed, _ := net.ParseCIDR("10.0.0.0/8")
+	feed := NewFeed(&fakeSource{}, time.Hour, time.Hour, Options{
+		MaxSensitivity: model.Sensitivity_SENSITIVITY_COMMERCIAL,
+		Bbox:           &geovision.BoundingBox{MinLatitude: 40, MinLongitude: 20, MaxLatitude: 55, MaxLongitude: 40},
+		MaxAge:         time.Hour,
+		Allowed:        []*net.IPNet{allowed},
+		Audit:          auditor,
+	})
+	now := time.Unix(100000, 0)
+	feed.now = func() time.Time { return now }
+
+	t.Run("Allowed Networks", func(t *testing.T) {
+		if !feed.allowed(&net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 4242}) {
+			t.Error("Expected a client inside the allowed networks to connect")
+		}
+		if feed.allowed(&net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 4242}) {
+			t.Error("Expected a client outside the allowed networks to be refused")
+		}
+	})
+
+	t.Run("UDP", func(t *testing.T) {
+		destination, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		defer destination.Close()
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		defer conn.Close()
+		feed.udpConn, feed.udpDestinations = conn, []net.Addr{destination.LocalAddr()}
+
+		kyiv := &model.LocationData{Latitude: 50.45, Longitude: 30.52}
+		feed.Publish([]*model.Event{
+			{Id: "events/1", Title: "Visible", HappenedAt: now.Unix() - 60, Location: kyiv, Sensitivity: model.Sensitivity_SENSITIVITY_PRIVILEGED},
+			{Id: "events/2", Title: "Confidential", HappenedAt: now.Unix() - 60, Location: kyiv, Sensitivity: model.Sensitivity_SENSITIVITY_CONFIDENTIAL},
+			{Id: "events/3", Title: "Elsewhere", HappenedAt: now.Unix() - 60, Location: &model.LocationData{Latitude: 10, Longitude: 10}},
+			{Id: "events/4", Title: "Old", HappenedAt: now.Unix() - 7200, Location: kyiv},
+		})
+
+		destination.SetReadDeadline(time.Now().Add(2 * time.Second))
+		buf := make([]byte, 64*1024)
+		n, _, err := destination.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("Failed to read CoT datagram: %v", err)
+		}
+		if !strings.Contains(string(buf[:n]), `callsign="Visible"`) {
+			t.Errorf("Expected the visible event, got %s", buf[:n])
+		}
+		destination.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		if n, _, err := destination.ReadFrom(buf); err == nil {
+			t.Errorf("Expected the other events to be filtered out, got %s", buf[:n])
+		}
+
+		if len(auditor.entries) != 1 {
+			t.Fatalf("Expected 1 audit entry, got %d", len(auditor.entries))
+		}
+		if entry := auditor.entries[0]; entry.RPC != FeedRPC || entry.Results != 1 || entry.Sensitivity != int32(model.Sensitivity_SENSITIVITY_PRIVILEGED) {
+			t.Errorf("Expected the pushed event to be recorded, got %+v", entry)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package tracing
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arangodb/go-driver"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the AQL spans.
+const tracerName = "github.com/omnsight/geovision/src/tracing"
+
+var (
+	// stringLiteral matches single or double quoted AQL strings
+	stringLiteral = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`)
+	// numberLiteral matches numbers that are not part of an identifier
+	numberLiteral = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+)
+
+// Database traces every AQL query, from the call until its cursor is
+// closed. Everything else goes straight to the wrapped database.
+type Database struct {
+	driver.Database
+}
+
+// InstrumentDatabase wraps db so that its queries are traced.
+func InstrumentDatabase(db driver.Database) driver.Database {
+	if _, ok := db.(*Database); ok {
+		return db
+	}
+	return &Database{Database: db}
+}
+
+// Query runs the query in a child span of the one in ctx. The span records
+// the sanitized query and the names of its bind parameters, never their
+// values.
+func (d *Database) Query(ctx context.Context, query string, bindVars map[string]interface{}) (driver.Cursor, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "aql.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameKey.String("arangodb"),
+			semconv.DBNamespace(d.Name()),
+			semconv.DBQueryText(SanitizeQuery(query)),
+			attribute.StringSlice("db.arangodb.bind_vars", bindNames(bindVars)),
+		),
+	)
+
+	cursor, err := d.Database.Query(ctx, query, bindVars)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, err
+	}
+	return &Cursor{Cursor: cursor, span: span}, nil
+}
+
+// Cursor ends the query span when closed.
+type Cursor struct {
+	driver.Cursor
+
+	span trace.Span
+	once sync.Once
+}
+
+// Close closes the cursor and ends the span.
+func (c *Cursor) Close() error {
+	err := c.Cursor.Close()
+	c.once.Do(func() {
+		if err != nil {
+			c.span.RecordError(err)
+		}
+		c.span.End()
+	})
+	return err
+}
+
+// SanitizeQuery collapses whitespace and replaces string and number literals
+// with a placeholder, so that no data written into a query ends up in traces.
+func SanitizeQuery(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+	query = numberLiteral.ReplaceAllString(query, "?")
+	return strings.Join(strings.Fields(query), " ")
+}
+
+func bindNames(bindVars map[string]interface{}) []string {
+	names := make([]string, 0, len(bindVars))
+	for name := range bindVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// ServiceName identifies the service in traces.
+const ServiceName = "geovision"
+
+// Setup installs a tracer provider exporting spans over OTLP/gRPC to endpoint,
+// a URL such as http://collector:4317, sending headers with every export.
+// Without an endpoint the global provider stays a no-op and nothing is
+// recorded. The exporter reads the rest of its settings, such as
+// OTEL_EXPORTER_OTLP_INSECURE, from the environment itself. The returned
+// function flushes pending spans.
+func Setup(ctx context.Context, endpoint string, headers map[string]string) (func(context.Context) error, error) {
+	// Propagate trace context even when not exporting, so that upstream
+	// traces pass through to downstream services
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpointURL(endpoint),
+		otlptracegrpc.WithHeaders(headers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe tracing resource: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,17 @@
+package tracing
+
+import "testing"
+
+func TestSanitizeQuery(t *testing.T) {
+	query := `
+		FOR doc IN @@collection
+			FILTER doc.happened_at >= @start_time && doc.title == "Strike on 'depot'"
+			FILTER doc.tags[0] == 'artillery' LIMIT 10
+			RETURN doc
+	`
+
+	want := `FOR doc IN @@collection FILTER doc.happened_at >= @start_time && doc.title == ? FILTER doc.tags[?] == ? LIMIT ? RETURN doc`
+	if got := SanitizeQuery(query); got != want {
+		t.Errorf("Unexpected sanitized query:\n%s\nwant:\n%s", got, want)
+	}
+}